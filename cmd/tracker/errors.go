@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+)
+
+// exitRejected — код завершения, если часть записей была отклонена.
+const exitRejected = 3
+
+// rejection — запись, которую не удалось обработать.
+type rejection struct {
+	record
+	err error
+}
+
+func (r rejection) String() string {
+	if r.line == 0 {
+		return fmt.Sprintf("%s: %v", r.source, r.err)
+	}
+	return fmt.Sprintf("%s:%d: %q: %v", r.source, r.line, r.text, r.err)
+}
+
+// printRejections выводит сводку по отклонённым записям на языке lang.
+func printRejections(w io.Writer, lang i18n.Lang, rejected []rejection) {
+	if len(rejected) == 0 {
+		return
+	}
+
+	fmt.Fprint(w, lang.Sprintf(i18n.Rejected, len(rejected)))
+	for _, r := range rejected {
+		fmt.Fprintf(w, "  %s\n", r)
+	}
+}
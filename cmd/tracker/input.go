@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/csvlog"
+)
+
+// stdinPath — имя файла, которое означает стандартный ввод.
+const stdinPath = "-"
+
+// Форматы входных файлов.
+const (
+	inputAuto = "auto" // определяется по расширению файла
+	inputText = "text"
+	inputCSV  = "csv"
+)
+
+var inputFormats = []string{inputAuto, inputText, inputCSV}
+
+// record — строка входных данных вместе с её местоположением.
+type record struct {
+	source string    // имя файла или "-" для стандартного ввода
+	line   int       // номер строки, начиная с 1
+	text   string    // запись в формате пакета daysteps или spentcalories
+	date   time.Time // дата записи, если она указана во входных данных
+	err    error     // ошибка разбора строки входного файла
+}
+
+// readRecords читает записи в формате format: текстовом или CSV.
+func readRecords(path, format string) ([]record, error) {
+	if format == inputAuto {
+		format = inputText
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			format = inputCSV
+		}
+	}
+
+	if format == inputText {
+		return readLines(path)
+	}
+	return readCSV(path)
+}
+
+// readCSV читает тренировки из CSV-файла path или из стандартного ввода.
+func readCSV(path string) ([]record, error) {
+	r, closeFile, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile()
+
+	rows, err := csvlog.Read(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	records := make([]record, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, record{source: path, line: row.Line, text: row.Data, date: row.Date, err: row.Err})
+	}
+	return records, nil
+}
+
+// open открывает файл path или возвращает стандартный ввод, если path равен "-".
+func open(path string) (io.Reader, func(), error) {
+	if path == stdinPath {
+		return os.Stdin, func() {}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("не удалось открыть файл: %w", err)
+	}
+	return f, func() { f.Close() }, nil
+}
+
+// readLines читает записи из файла path или из стандартного ввода, если path равен "-".
+// Пустые строки и строки, начинающиеся с #, пропускаются, но учитываются в нумерации.
+func readLines(path string) ([]record, error) {
+	r, closeFile, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile()
+
+	lines, err := scanLines(r, path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать файл %s: %w", path, err)
+	}
+	return lines, nil
+}
+
+func scanLines(r io.Reader, source string) ([]record, error) {
+	var lines []record
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, record{source: source, line: n, text: line})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
@@ -1,70 +1,319 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/goals"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/layout"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
 	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/storage"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+	"github.com/Yandex-Practicum/tracker/internal/trackfile"
+	"github.com/Yandex-Practicum/tracker/internal/units"
 )
 
 func main() {
-	weight := 84.6
-	height := 1.87
+	var (
+		daysPath      = flag.String("days", "", "файл с дневной активностью в формате \"шаги,продолжительность\" (- для стандартного ввода)")
+		trainingsPath = flag.String("trainings", "", "файл с тренировками в формате \"шаги,вид,продолжительность\" (- для стандартного ввода)")
+		weight        = flag.Float64("weight", 0, "вес в кг или в фунтах с -units imperial")
+		height        = flag.String("height", "", "рост в м или в футах и дюймах с -units imperial, например 5'11\"")
+		unitSystem    = flag.String("units", string(units.Metric), "система единиц для ввода профиля и текстового вывода: metric или imperial")
+		age           = flag.Int("age", 0, "возраст в годах")
+		sex           = flag.String("sex", "", "пол: male или female")
+		strideModel   = flag.String("stride", "", "модель длины шага: fixed, height или calibrated")
+		calorieModel  = flag.String("calories", "", "модель расчёта калорий для бега и ходьбы: formula или met")
+		strict        = flag.Bool("strict", false, "прекращать обработку при первой ошибочной записи")
+		format        = flag.String("format", formatText, "формат вывода: text, json, jsonl или csv")
+		inputFormat   = flag.String("input", inputAuto, "формат файла с тренировками: auto (по расширению), text или csv")
+		tracksPaths   = flag.String("tracks", "", "файлы треков GPX или TCX через запятую")
+		trackActivity = flag.String("activity", "", "вид тренировки для треков; по умолчанию определяется по файлу")
+		reportPeriod  = flag.String("report", "", "вывести сводку по периодам вместо отдельных записей: day, week или month")
+		layoutName    = flag.String("layout", layout.Verbose, "макет текстового вывода записей: "+strings.Join(layout.Builtins(), ", "))
+		templatePath  = flag.String("template", "", "файл с шаблоном text/template для текстового вывода записей вместо -layout")
+		langName      = flag.String("lang", string(i18n.Default), "язык вывода: ru или en")
+		historyPath   = flag.String("history", "", "файл истории: рассчитанные записи с датой сохраняются в него, а сводка строится по всей истории")
+	)
+	var goalList []goals.Goal
+	flag.Func("goal", "цель вида показатель=значение[/период], например steps=10000/day или trainings=3/week; флаг можно повторять", func(s string) error {
+		g, err := goals.Parse(s)
+		if err != nil {
+			return err
+		}
+		goalList = append(goalList, g)
+		return nil
+	})
+	flag.Parse()
 
-	// дневная активность
-	input := []string{
-		"678,0h50m",
-		"792,1h14m",
-		"1078,1h30m",
-		"7830,2h40m",
-		",3456",
-		"12:40:00, 3456",
-		"something is wrong",
+	if *daysPath == "" && *trainingsPath == "" && *tracksPaths == "" && (*historyPath == "" || *reportPeriod == "") {
+		fmt.Fprintln(os.Stderr, "нужно указать хотя бы один из флагов -days, -trainings или -tracks либо -history вместе с -report")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if !slices.Contains(formats, *format) {
+		fmt.Fprintf(os.Stderr, "неизвестный формат вывода: %q\n", *format)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if !slices.Contains(inputFormats, *inputFormat) {
+		fmt.Fprintf(os.Stderr, "неизвестный формат входного файла: %q\n", *inputFormat)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == formatCSV && *daysPath != "" {
+		fmt.Fprintln(os.Stderr, "формат csv поддерживается только для тренировок")
+		os.Exit(2)
+	}
+	if *reportPeriod != "" {
+		period, err := report.ParsePeriod(*reportPeriod)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			flag.Usage()
+			os.Exit(2)
+		}
+		if *format == formatCSV {
+			fmt.Fprintln(os.Stderr, "формат csv не поддерживается для сводок")
+			os.Exit(2)
+		}
+		*reportPeriod = string(period)
+	}
+	lang, err := i18n.Parse(*langName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	system, err := units.Parse(*unitSystem)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *daysPath == stdinPath && *trainingsPath == stdinPath {
+		log.Fatal("стандартный ввод можно использовать только для одного из флагов -days и -trainings")
 	}
 
-	fmt.Println("Активность в течение дня")
+	heightM, err := system.ParseHeight(*height)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	var (
-		dayActionsInfo string
-		dayActionsLog  []string
-	)
+	user := profile.Profile{
+		Weight: system.Kilograms(*weight),
+		Height: heightM,
+		Age:    *age,
+	}
 
-	for _, v := range input {
-		dayActionsInfo = daysteps.DayActionInfo(v, weight, height)
-		dayActionsLog = append(dayActionsLog, dayActionsInfo)
+	if user.Sex, err = profile.ParseSex(*sex); err != nil {
+		log.Fatal(err)
+	}
+	if *strideModel != "" {
+		if user.StrideModel, err = stride.Parse(*strideModel); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *calorieModel != "" {
+		if user.CalorieModel, err = spentcalories.ParseCalorieModel(*calorieModel); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := user.Validate(); err != nil {
+		log.Fatalf("неверные данные пользователя: %v", err)
+	}
+
+	var rejected []rejection
+
+	// reject запоминает ошибочную запись, а в строгом режиме сразу завершает работу.
+	reject := func(r record, err error) {
+		if *strict {
+			log.Printf("%s:%d: не получилось обработать запись: %v", r.source, r.line, err)
+			os.Exit(1)
+		}
+		rejected = append(rejected, rejection{record: r, err: err})
+	}
+
+	out, err := loadLayout(*layoutName, *templatePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	res := results{lang: lang, units: system, layout: out}
+
+	if *daysPath != "" {
+		input, err := readLines(*daysPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		res.hasDays = true
+		res.DayActions, res.DayTotals = computeDayActions(input, user, reject)
 	}
 
-	for _, v := range dayActionsLog {
-		fmt.Println(v)
+	if *trainingsPath != "" {
+		trainings, err := readRecords(*trainingsPath, *inputFormat)
+		if err != nil {
+			log.Fatal(err)
+		}
+		res.hasTrainings = true
+		res.Trainings = computeTrainings(trainings, user, reject)
 	}
 
-	// тренировки
-	trainings := []string{
-		"3456,Ходьба,3h00m",
-		"something is wrong",
-		"678,Бег,0h5m",
-		"1078,Бег,0h10m",
-		",3456 Ходьба",
-		"7892,Ходьба,3h10m",
-		"15392,Бег,0h45m",
+	if *tracksPaths != "" {
+		res.hasTrainings = true
+		res.Trainings = append(res.Trainings, computeTracks(strings.Split(*tracksPaths, ","), *trackActivity, user, reject)...)
 	}
 
-	var trainingLog []string
+	entries := res.entries()
+	if *historyPath != "" {
+		if entries, err = saveHistory(storage.NewFile(*historyPath), entries); err != nil {
+			log.Fatalf("не получилось обновить историю: %v", err)
+		}
+	}
+
+	if len(goalList) > 0 {
+		res.Goals = goals.Track(goalList, entries)
+	}
+
+	if *reportPeriod != "" {
+		err = writeReport(os.Stdout, *format, lang, system, report.Build(entries, report.Period(*reportPeriod)))
+	} else {
+		err = write(os.Stdout, *format, res)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(rejected) > 0 {
+		printRejections(os.Stderr, lang, rejected)
+		os.Exit(exitRejected)
+	}
+}
+
+// loadLayout возвращает макет из файла с шаблоном path, а если файл не указан — встроенный макет name.
+func loadLayout(name, path string) (*layout.Layout, error) {
+	if path == "" {
+		return layout.Builtin(name)
+	}
+
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return layout.New(string(text))
+}
+
+// saveHistory сохраняет записи с датой в историю и возвращает всю историю.
+// Записи без даты в историю не попадают, о них выводится предупреждение.
+func saveHistory(repo storage.Repository, entries []report.Entry) ([]report.Entry, error) {
+	dated := slices.DeleteFunc(slices.Clone(entries), func(e report.Entry) bool {
+		return e.Date.IsZero()
+	})
+	if skipped := len(entries) - len(dated); skipped > 0 {
+		log.Printf("предупреждение: записей без даты: %d, они не сохранены в историю", skipped)
+	}
+	if len(dated) > 0 {
+		if err := repo.Save(dated...); err != nil {
+			return nil, err
+		}
+	}
+	return repo.List()
+}
+
+// computeDayActions рассчитывает дневную активность и итоги по дням для записей со временем начала.
+// Ошибочные записи и записи, пересекающиеся по времени с другими, передаются в reject.
+func computeDayActions(input []record, user profile.Profile, reject func(record, error)) ([]daysteps.DayAction, []daysteps.DayTotals) {
+	var (
+		dayActions []daysteps.DayAction
+		journal    daysteps.Journal
+	)
+
+	for _, v := range input {
+		dayAction, err := daysteps.ComputeDayAction(v.text, user)
+		if err != nil {
+			reject(v, err)
+			continue
+		}
+		if !dayAction.Start.IsZero() {
+			if err := journal.Add(dayAction); err != nil {
+				reject(v, err)
+				continue
+			}
+		}
+		dayActions = append(dayActions, dayAction)
+	}
+
+	return dayActions, journal.AllTotals()
+}
+
+// computeTrainings рассчитывает тренировки. Ошибочные записи передаются в reject.
+func computeTrainings(trainings []record, user profile.Profile, reject func(record, error)) []spentcalories.Training {
+	var trainingLog []spentcalories.Training
 
 	for _, v := range trainings {
-		trainingInfo, err := spentcalories.TrainingInfo(v, weight, height)
+		if v.err != nil {
+			reject(v, v.err)
+			continue
+		}
+
+		training, err := spentcalories.ComputeTraining(v.text, user)
 		if err != nil {
-			log.Printf("не получилось получить информацию о тренировке: %v", err)
-			os.Exit(1)
+			reject(v, err)
+			continue
 		}
-		trainingLog = append(trainingLog, trainingInfo)
+		training.Date = v.date
+		trainingLog = append(trainingLog, training)
 	}
 
-	fmt.Println("Журнал тренировок")
+	return trainingLog
+}
+
+// computeTracks рассчитывает тренировки по файлам треков. Если вид тренировки activity не задан,
+// он определяется по файлу. Если в треке записан пульс, а в профиле указаны возраст и пол,
+// калории считаются по пульсу. Файлы, которые не удалось обработать, передаются в reject.
+func computeTracks(paths []string, activity string, user profile.Profile, reject func(record, error)) []spentcalories.Training {
+	var trainingLog []spentcalories.Training
 
-	for _, v := range trainingLog {
-		fmt.Println(v)
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		rec := record{source: path}
+
+		track, err := trackfile.ReadFile(path)
+		if err != nil {
+			reject(rec, err)
+			continue
+		}
+
+		name := activity
+		if name == "" {
+			name = track.Activity()
+		}
+		if name == "" {
+			reject(rec, fmt.Errorf("не удалось определить вид тренировки %q, укажите его флагом -activity", track.Sport))
+			continue
+		}
+
+		w := track.Workout()
+		if hr := track.HeartRate(); hr > 0 && user.Age > 0 && user.Sex != "" {
+			w.Params = map[string]string{spentcalories.HeartRateParam: strconv.FormatFloat(hr, 'f', 0, 64)}
+		}
+
+		training, err := spentcalories.ComputeWorkout(name, w, user)
+		if err != nil {
+			reject(rec, err)
+			continue
+		}
+		training.Date = track.Start()
+		trainingLog = append(trainingLog, training)
 	}
+
+	return trainingLog
 }
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/Yandex-Practicum/tracker/internal/csvlog"
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/goals"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/layout"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Форматы вывода.
+const (
+	formatText      = "text"
+	formatJSON      = "json"
+	formatJSONLines = "jsonl"
+	formatCSV       = "csv"
+)
+
+var formats = []string{formatText, formatJSON, formatJSONLines, formatCSV}
+
+// results — рассчитанные записи, которые нужно вывести.
+type results struct {
+	DayActions []daysteps.DayAction     `json:"day_actions,omitempty"`
+	DayTotals  []daysteps.DayTotals     `json:"day_totals,omitempty"`
+	Trainings  []spentcalories.Training `json:"trainings,omitempty"`
+	Goals      []goals.Result           `json:"goals,omitempty"`
+
+	hasDays      bool           // запрошен вывод дневной активности
+	hasTrainings bool           // запрошен вывод тренировок
+	lang         i18n.Lang      // язык текстового вывода
+	units        units.System   // система единиц текстового вывода
+	layout       *layout.Layout // макет текстового вывода записей
+}
+
+// write выводит результаты в выбранном формате.
+func write(w io.Writer, format string, res results) error {
+	switch format {
+	case formatJSON:
+		return writeJSON(w, res)
+	case formatJSONLines:
+		return writeJSONLines(w, res)
+	case formatCSV:
+		return csvlog.Write(w, res.Trainings)
+	}
+	return writeText(w, res)
+}
+
+func writeText(w io.Writer, res results) error {
+	lang, system := res.lang, res.units
+
+	if res.hasDays {
+		fmt.Fprintln(w, lang.Sprintf(i18n.DayActionsTitle))
+		if err := res.layout.DayActions(w, lang, system, res.DayActions); err != nil {
+			return err
+		}
+
+		if len(res.DayTotals) > 0 {
+			fmt.Fprintln(w, lang.Sprintf(i18n.DayTotalsTitle))
+			for _, v := range res.DayTotals {
+				fmt.Fprintln(w, v.Text(lang, system))
+			}
+			fmt.Fprintln(w)
+		}
+
+		writeGoals(w, lang, system, res.Goals)
+	}
+
+	if res.hasTrainings {
+		fmt.Fprintln(w, lang.Sprintf(i18n.TrainingsTitle))
+		if err := res.layout.Trainings(w, lang, system, res.Trainings); err != nil {
+			return err
+		}
+
+		if !res.hasDays {
+			writeGoals(w, lang, system, res.Goals)
+		}
+	}
+
+	return nil
+}
+
+// writeGoals выводит выполнение целей за последний период. Без дневной активности
+// цели выводятся после журнала тренировок. Цели, для которых нет ни одной записи, не выводятся.
+func writeGoals(w io.Writer, lang i18n.Lang, system units.System, results []goals.Result) {
+	results = slices.DeleteFunc(slices.Clone(results), func(r goals.Result) bool {
+		return len(r.Periods) == 0
+	})
+	if len(results) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, lang.Sprintf(i18n.GoalsTitle))
+	for _, v := range results {
+		fmt.Fprintln(w, v.Text(lang, system))
+	}
+	fmt.Fprintln(w)
+}
+
+// writeJSON выводит весь журнал одним JSON-объектом с массивами day_actions и trainings.
+func writeJSON(w io.Writer, res results) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
+
+// writeJSONLines выводит каждую запись отдельным JSON-объектом на своей строке:
+// {"day_action":{...}}, {"day_total":{...}}, {"training":{...}} или {"goal":{...}}.
+func writeJSONLines(w io.Writer, res results) error {
+	enc := json.NewEncoder(w)
+
+	for _, v := range res.DayActions {
+		if err := enc.Encode(map[string]daysteps.DayAction{"day_action": v}); err != nil {
+			return err
+		}
+	}
+	for _, v := range res.DayTotals {
+		if err := enc.Encode(map[string]daysteps.DayTotals{"day_total": v}); err != nil {
+			return err
+		}
+	}
+	for _, v := range res.Trainings {
+		if err := enc.Encode(map[string]spentcalories.Training{"training": v}); err != nil {
+			return err
+		}
+	}
+	for _, v := range res.Goals {
+		if err := enc.Encode(map[string]goals.Result{"goal": v}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// entries преобразует дневную активность и тренировки в записи для сводки.
+func (res results) entries() []report.Entry {
+	entries := make([]report.Entry, 0, len(res.DayActions)+len(res.Trainings))
+	for _, v := range res.DayActions {
+		entries = append(entries, report.FromDayAction(v))
+	}
+	for _, v := range res.Trainings {
+		entries = append(entries, report.FromTraining(v))
+	}
+	return entries
+}
+
+// writeReport выводит сводки по периодам в выбранном формате; текст выводится на языке lang
+// с дистанцией в системе единиц system. В формате jsonl каждая сводка выводится как {"summary":{...}}.
+func writeReport(w io.Writer, format string, lang i18n.Lang, system units.System, summaries []report.Summary) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string][]report.Summary{"summaries": summaries})
+	case formatJSONLines:
+		enc := json.NewEncoder(w)
+		for _, v := range summaries {
+			if err := enc.Encode(map[string]report.Summary{"summary": v}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(summaries) == 0 {
+		fmt.Fprintln(w, lang.Sprintf(i18n.ReportEmpty))
+		return nil
+	}
+	for _, v := range summaries {
+		fmt.Fprintln(w, v.Text(lang, system))
+	}
+	return nil
+}
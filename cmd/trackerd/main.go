@@ -0,0 +1,62 @@
+// Команда trackerd запускает HTTP-сервер с расчётами трекера.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/api"
+	"github.com/Yandex-Practicum/tracker/internal/storage"
+)
+
+// shutdownTimeout — время на завершение обработки запросов при остановке сервера.
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	var (
+		addr        = flag.String("addr", ":8080", "адрес, на котором сервер принимает запросы")
+		historyPath = flag.String("history", "", "файл истории в формате JSON Lines; если не указан, история хранится в памяти")
+	)
+	flag.Parse()
+
+	var repo storage.Repository = &storage.Memory{}
+	if *historyPath != "" {
+		repo = storage.NewFile(*historyPath)
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           api.New(repo),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("не получилось остановить сервер: %v", err)
+		}
+	}()
+
+	log.Printf("сервер запущен на %s", *addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	<-stopped
+}
@@ -0,0 +1,223 @@
+// Package api предоставляет HTTP-интерфейс к расчётам трекера и истории активности.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/input"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/storage"
+)
+
+// maxBodySize — наибольший допустимый размер тела запроса в байтах.
+const maxBodySize = 1 << 20
+
+// Server обрабатывает HTTP-запросы. Рассчитанные записи с датой сохраняются в историю.
+//
+// Маршруты:
+//
+//	POST /v1/day-actions — расчёт дневной активности;
+//	POST /v1/trainings   — расчёт тренировки;
+//	GET  /v1/history     — записи истории с фильтрами from, to и activity;
+//	GET  /v1/report      — сводка истории по периодам period=day|week|month.
+type Server struct {
+	repo storage.Repository
+	mux  *http.ServeMux
+}
+
+// New возвращает сервер, который хранит историю в repo.
+func New(repo storage.Repository) *Server {
+	s := &Server{repo: repo, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("POST /v1/day-actions", s.handleDayAction)
+	s.mux.HandleFunc("POST /v1/trainings", s.handleTraining)
+	s.mux.HandleFunc("GET /v1/history", s.handleHistory)
+	s.mux.HandleFunc("GET /v1/report", s.handleReport)
+
+	return s
+}
+
+// ServeHTTP реализует http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleDayAction(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeDayAction(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	action, err := daysteps.ComputeDayAction(req.Data, req.Profile)
+	if err != nil {
+		writeError(w, computeError(err))
+		return
+	}
+	if !req.Start.IsZero() {
+		action.Start = req.Start
+	}
+
+	if err := s.save(report.FromDayAction(action)); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, action)
+}
+
+func (s *Server) handleTraining(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeTraining(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	training, err := spentcalories.ComputeTraining(req.Data, req.Profile)
+	if err != nil {
+		writeError(w, computeError(err))
+		return
+	}
+
+	training.Date = req.Date
+
+	if err := s.save(report.FromTraining(training)); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, training)
+}
+
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	q, err := parseQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entries, err := s.repo.Query(q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if entries == nil {
+		entries = []report.Entry{}
+	}
+	writeJSON(w, http.StatusOK, map[string][]report.Entry{"entries": entries})
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	period := report.Day
+	if value := r.URL.Query().Get("period"); value != "" {
+		var err error
+		if period, err = report.ParsePeriod(value); err != nil {
+			writeError(w, badRequest(err))
+			return
+		}
+	}
+
+	q, err := parseQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entries, err := s.repo.Query(q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string][]report.Summary{"summaries": report.Build(entries, period)})
+}
+
+// save сохраняет запись в историю, если у неё есть дата.
+func (s *Server) save(e report.Entry) error {
+	if e.Date.IsZero() {
+		return nil
+	}
+	return s.repo.Save(e)
+}
+
+// requestError — ошибка в данных запроса, которая не относится к разбору записи.
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+
+func (e *requestError) Unwrap() error { return e.err }
+
+// badRequest помечает ошибку как ошибку данных запроса.
+func badRequest(err error) error {
+	return &requestError{status: http.StatusBadRequest, err: err}
+}
+
+// computeError помечает ошибку расчёта, которая не относится к разбору записи, кодом 422.
+func computeError(err error) error {
+	if status(err) != http.StatusInternalServerError {
+		return err
+	}
+	return &requestError{status: http.StatusUnprocessableEntity, err: err}
+}
+
+// errorResponse — тело ответа с ошибкой.
+type errorResponse struct {
+	Error    string `json:"error"`
+	Field    string `json:"field,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Position int    `json:"position,omitempty"`
+}
+
+// status возвращает код ответа для ошибки:
+// 400 — неверные данные запроса или записи, 422 — запись разобрана, но её нельзя рассчитать,
+// 500 — ошибка сервера, например хранилища.
+func status(err error) int {
+	var reqErr *requestError
+	switch {
+	case errors.As(err, &reqErr):
+		return reqErr.status
+	case errors.Is(err, input.ErrUnknownActivity):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, input.ErrInvalidFormat),
+		errors.Is(err, input.ErrInvalidSteps),
+		errors.Is(err, input.ErrInvalidDuration),
+		errors.Is(err, input.ErrInvalidDistance),
+		errors.Is(err, input.ErrInvalidParam),
+		errors.Is(err, input.ErrInvalidTime):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// writeError записывает ответ с ошибкой. Для ошибок разбора указывается поле и его значение.
+// Текст внутренних ошибок клиенту не передаётся.
+func writeError(w http.ResponseWriter, err error) {
+	code := status(err)
+
+	resp := errorResponse{Error: err.Error()}
+	if code == http.StatusInternalServerError {
+		log.Printf("api: %v", err)
+		resp.Error = http.StatusText(code)
+	}
+
+	var parseErr *input.ParseError
+	if errors.As(err, &parseErr) {
+		resp.Field, resp.Value, resp.Position = parseErr.Field, parseErr.Value, parseErr.Pos
+	}
+
+	writeJSON(w, code, resp)
+}
+
+// writeJSON записывает ответ в формате JSON.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("api: не получилось записать ответ: %v", err)
+	}
+}
@@ -0,0 +1,264 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Yandex-Practicum/tracker/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type APITestSuite struct {
+	suite.Suite
+	repo   *storage.Memory
+	server *Server
+}
+
+func TestAPISuite(t *testing.T) {
+	suite.Run(t, new(APITestSuite))
+}
+
+func (suite *APITestSuite) SetupTest() {
+	suite.repo = &storage.Memory{}
+	suite.server = New(suite.repo)
+}
+
+// do выполняет запрос и возвращает код ответа и разобранное тело.
+func (suite *APITestSuite) do(method, target, contentType, body string) (int, map[string]any) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	suite.server.ServeHTTP(rec, req)
+
+	var resp map[string]any
+	if rec.Body.Len() > 0 && strings.HasPrefix(rec.Header().Get("Content-Type"), contentJSON) {
+		require.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &resp), rec.Body.String())
+	}
+	return rec.Code, resp
+}
+
+const testProfile = `"profile":{"weight_kg":84.6,"height_m":1.87}`
+
+func (suite *APITestSuite) TestTraining() {
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+	}{
+		{
+			name:        "строка в text/plain",
+			target:      "/v1/trainings?weight=84.6&height=1.87",
+			contentType: "text/plain; charset=utf-8",
+			body:        "3456,Ходьба,3h00m\n",
+		},
+		{
+			name:   "строка без типа содержимого",
+			target: "/v1/trainings?weight=84.6&height=1.87",
+			body:   "3456,Ходьба,3h00m",
+		},
+		{
+			name:        "строка в JSON",
+			target:      "/v1/trainings",
+			contentType: contentJSON,
+			body:        `{` + testProfile + `,"data":"3456,Ходьба,3h00m"}`,
+		},
+		{
+			name:        "поля в JSON",
+			target:      "/v1/trainings",
+			contentType: contentJSON,
+			body:        `{` + testProfile + `,"steps":3456,"activity":"Ходьба","duration":"3h00m"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			code, resp := suite.do(http.MethodPost, tt.target, tt.contentType, tt.body)
+			require.Equal(suite.T(), http.StatusOK, code, resp)
+			assert.Equal(suite.T(), "Ходьба", resp["type"])
+			assert.InDelta(suite.T(), 10800.0, resp["duration_s"], 1e-9)
+			assert.InDelta(suite.T(), 2.91, resp["distance_km"], 0.01)
+			assert.InDelta(suite.T(), 0.97, resp["speed_kmh"], 0.01)
+			assert.InDelta(suite.T(), 123.02, resp["calories_kcal"], 0.01)
+		})
+	}
+}
+
+func (suite *APITestSuite) TestTrainingWithParams() {
+	body := `{` + testProfile + `,"steps":30,"activity":"Плавание","duration":"50m","params":{"pool":"50","stroke":"брасс"}}`
+
+	code, resp := suite.do(http.MethodPost, "/v1/trainings", contentJSON, body)
+	require.Equal(suite.T(), http.StatusOK, code, resp)
+	assert.InDelta(suite.T(), 1.5, resp["distance_km"], 1e-9)
+	assert.InDelta(suite.T(), 5.3*84.6*50/60, resp["calories_kcal"], 1e-9)
+}
+
+func (suite *APITestSuite) TestDayAction() {
+	code, resp := suite.do(http.MethodPost, "/v1/day-actions?weight=75&height=1.75", contentText, "2024-05-01 08:00,6000,1h00m")
+	require.Equal(suite.T(), http.StatusOK, code, resp)
+	assert.InDelta(suite.T(), 6000.0, resp["steps"], 1e-9)
+	assert.InDelta(suite.T(), 4.725, resp["distance_km"], 1e-9)
+	assert.Contains(suite.T(), resp["start"], "2024-05-01T08:00:00")
+
+	code, resp = suite.do(http.MethodPost, "/v1/day-actions", contentJSON,
+		`{"profile":{"weight_kg":75,"height_m":1.75},"start":"2024-05-02T08:00:00Z","steps":6000,"duration":"1h"}`)
+	require.Equal(suite.T(), http.StatusOK, code, resp)
+	assert.Equal(suite.T(), "2024-05-02T08:00:00Z", resp["start"])
+}
+
+func (suite *APITestSuite) TestErrors() {
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		code        int
+		field       string
+	}{
+		{
+			name:   "неверное количество шагов",
+			target: "/v1/trainings?weight=84.6&height=1.87",
+			body:   "-100,Бег,1h00m",
+			code:   http.StatusBadRequest,
+			field:  "шаги",
+		},
+		{
+			name:   "неверный формат строки",
+			target: "/v1/day-actions?weight=84.6&height=1.87",
+			body:   "something is wrong",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "неверная продолжительность",
+			target: "/v1/trainings?weight=84.6&height=1.87",
+			body:   "6000,Бег,час",
+			code:   http.StatusBadRequest,
+			field:  "продолжительность",
+		},
+		{
+			name:   "неизвестный вид тренировки",
+			target: "/v1/trainings?weight=84.6&height=1.87",
+			body:   "6000,Теннис,1h00m",
+			code:   http.StatusUnprocessableEntity,
+			field:  "вид",
+		},
+		{
+			name:   "неверный параметр",
+			target: "/v1/trainings?weight=84.6&height=1.87",
+			body:   "40,Плавание,1h00m,pool=-5",
+			code:   http.StatusBadRequest,
+			field:  "pool",
+		},
+		{
+			name:   "не указан вес",
+			target: "/v1/trainings?height=1.87",
+			body:   "6000,Бег,1h00m",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:        "неверный JSON",
+			target:      "/v1/trainings",
+			contentType: contentJSON,
+			body:        `{` + testProfile + `,"data":`,
+			code:        http.StatusBadRequest,
+		},
+		{
+			name:        "неизвестное поле JSON",
+			target:      "/v1/trainings",
+			contentType: contentJSON,
+			body:        `{` + testProfile + `,"data":"6000,Бег,1h00m","pulse":150}`,
+			code:        http.StatusBadRequest,
+		},
+		{
+			name:        "неподдерживаемый тип содержимого",
+			target:      "/v1/trainings?weight=84.6&height=1.87",
+			contentType: "application/xml",
+			body:        "<training/>",
+			code:        http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			code, resp := suite.do(http.MethodPost, tt.target, tt.contentType, tt.body)
+			assert.Equal(suite.T(), tt.code, code, resp)
+			assert.NotEmpty(suite.T(), resp["error"])
+			if tt.field != "" {
+				assert.Equal(suite.T(), tt.field, resp["field"])
+			}
+		})
+	}
+}
+
+func (suite *APITestSuite) TestMethodNotAllowed() {
+	code, _ := suite.do(http.MethodGet, "/v1/trainings", "", "")
+	assert.Equal(suite.T(), http.StatusMethodNotAllowed, code)
+}
+
+func (suite *APITestSuite) TestHistory() {
+	records := []struct{ target, body string }{
+		{"/v1/trainings?weight=75&height=1.75&date=2024-05-01+07:30", "6000,Бег,40m"},
+		{"/v1/trainings?weight=75&height=1.75&date=2024-05-08+07:30", "8000,Ходьба,1h"},
+		{"/v1/day-actions?weight=75&height=1.75", "2024-05-02 12:00,4000,30m"},
+		{"/v1/trainings?weight=75&height=1.75", "5000,Бег,30m"},
+	}
+	for _, r := range records {
+		code, resp := suite.do(http.MethodPost, r.target, contentText, r.body)
+		require.Equal(suite.T(), http.StatusOK, code, resp)
+	}
+
+	entries, err := suite.repo.List()
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), entries, 3, "записи без даты не сохраняются")
+
+	code, resp := suite.do(http.MethodGet, "/v1/history?from=2024-05-01&to=2024-05-08", "", "")
+	require.Equal(suite.T(), http.StatusOK, code, resp)
+	require.Len(suite.T(), resp["entries"], 2)
+	assert.Equal(suite.T(), "Бег", resp["entries"].([]any)[0].(map[string]any)["activity"])
+
+	code, resp = suite.do(http.MethodGet, "/v1/history?activity=ходьба", "", "")
+	require.Equal(suite.T(), http.StatusOK, code, resp)
+	assert.Len(suite.T(), resp["entries"], 1)
+
+	code, resp = suite.do(http.MethodGet, "/v1/history?activity=Теннис", "", "")
+	require.Equal(suite.T(), http.StatusOK, code, resp)
+	assert.Equal(suite.T(), []any{}, resp["entries"])
+
+	code, resp = suite.do(http.MethodGet, "/v1/history?from=вчера", "", "")
+	assert.Equal(suite.T(), http.StatusBadRequest, code, resp)
+}
+
+func (suite *APITestSuite) TestReport() {
+	for _, date := range []string{"2024-05-01+07:30", "2024-05-03+07:30", "2024-05-08+07:30"} {
+		code, resp := suite.do(http.MethodPost, "/v1/trainings?weight=75&height=1.75&date="+date, contentText, "6000,Бег,40m")
+		require.Equal(suite.T(), http.StatusOK, code, resp)
+	}
+
+	code, resp := suite.do(http.MethodGet, "/v1/report?period=week", "", "")
+	require.Equal(suite.T(), http.StatusOK, code, resp)
+	summaries := resp["summaries"].([]any)
+	require.Len(suite.T(), summaries, 2)
+	first := summaries[0].(map[string]any)
+	assert.Equal(suite.T(), "2024-04-29", first["start"])
+	assert.InDelta(suite.T(), 2.0, first["active_days"], 1e-9)
+
+	code, resp = suite.do(http.MethodGet, "/v1/report?period=year", "", "")
+	assert.Equal(suite.T(), http.StatusBadRequest, code, resp)
+}
+
+func (suite *APITestSuite) TestCalorieModel() {
+	code, resp := suite.do(http.MethodPost, "/v1/trainings?weight=75&height=1.75&calories=met", contentText, "6000,Ходьба,1h00m")
+	require.Equal(suite.T(), http.StatusOK, code, resp)
+	assert.InDelta(suite.T(), 3.0*75, resp["calories_kcal"], 1e-9)
+
+	code, resp = suite.do(http.MethodPost, "/v1/trainings", contentJSON,
+		`{"profile":{"weight_kg":75,"height_m":1.75,"calories":"neural"},"data":"6000,Ходьба,1h00m"}`)
+	assert.Equal(suite.T(), http.StatusBadRequest, code, resp)
+}
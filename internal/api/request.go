@@ -0,0 +1,285 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/input"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/storage"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+)
+
+// Типы содержимого запросов на расчёт.
+const (
+	contentJSON = "application/json"
+	contentText = "text/plain"
+)
+
+// profileJSON — данные пользователя в теле запроса.
+type profileJSON struct {
+	WeightKg float64 `json:"weight_kg"`
+	HeightM  float64 `json:"height_m"`
+	Age      int     `json:"age,omitempty"`
+	Sex      string  `json:"sex,omitempty"`
+	Stride   string  `json:"stride,omitempty"`   // модель длины шага: fixed, height или calibrated
+	Calories string  `json:"calories,omitempty"` // модель расчёта калорий: formula или met
+}
+
+// profile преобразует данные пользователя в профиль и проверяет их.
+func (p profileJSON) profile() (profile.Profile, error) {
+	user := profile.Profile{Weight: p.WeightKg, Height: p.HeightM, Age: p.Age}
+
+	var err error
+	if user.Sex, err = profile.ParseSex(p.Sex); err != nil {
+		return profile.Profile{}, badRequest(err)
+	}
+	if p.Stride != "" {
+		if user.StrideModel, err = stride.Parse(p.Stride); err != nil {
+			return profile.Profile{}, badRequest(err)
+		}
+	}
+	if p.Calories != "" {
+		if user.CalorieModel, err = spentcalories.ParseCalorieModel(p.Calories); err != nil {
+			return profile.Profile{}, badRequest(err)
+		}
+	}
+	if err := user.Validate(); err != nil {
+		return profile.Profile{}, badRequest(fmt.Errorf("неверные данные пользователя: %w", err))
+	}
+	return user, nil
+}
+
+// dayActionJSON — запрос на расчёт дневной активности в формате JSON.
+// Запись задаётся строкой data в формате "[время,]шаги,продолжительность"
+// или полями steps и duration.
+type dayActionJSON struct {
+	Profile  profileJSON `json:"profile"`
+	Data     string      `json:"data,omitempty"`
+	Start    time.Time   `json:"start,omitzero"`
+	Steps    int         `json:"steps,omitempty"`
+	Duration string      `json:"duration,omitempty"`
+}
+
+// trainingJSON — запрос на расчёт тренировки в формате JSON.
+// Тренировка задаётся строкой data в формате "шаги,вид,продолжительность[,параметр=значение...]"
+// или полями steps либо distance_km, activity, duration и params.
+type trainingJSON struct {
+	Profile    profileJSON       `json:"profile"`
+	Data       string            `json:"data,omitempty"`
+	Date       time.Time         `json:"date,omitzero"`
+	Steps      int               `json:"steps,omitempty"`
+	DistanceKm float64           `json:"distance_km,omitempty"`
+	Activity   string            `json:"activity,omitempty"`
+	Duration   string            `json:"duration,omitempty"`
+	Params     map[string]string `json:"params,omitempty"`
+}
+
+// dayActionRequest — разобранный запрос на расчёт дневной активности.
+type dayActionRequest struct {
+	Profile profile.Profile
+	Data    string
+	Start   time.Time // время начала, если оно задано отдельно от строки
+}
+
+// trainingRequest — разобранный запрос на расчёт тренировки.
+type trainingRequest struct {
+	Profile profile.Profile
+	Data    string
+	Date    time.Time
+}
+
+// decodeDayAction разбирает запрос на расчёт дневной активности.
+// Тело запроса text/plain — строка записи, данные пользователя передаются в параметрах URL.
+func decodeDayAction(r *http.Request) (dayActionRequest, error) {
+	isJSON, body, err := readBody(r)
+	if err != nil {
+		return dayActionRequest{}, err
+	}
+
+	if !isJSON {
+		p, err := queryProfile(r.URL.Query())
+		if err != nil {
+			return dayActionRequest{}, err
+		}
+		return dayActionRequest{Profile: p, Data: body}, nil
+	}
+
+	var v dayActionJSON
+	if err := unmarshal(body, &v); err != nil {
+		return dayActionRequest{}, err
+	}
+	p, err := v.Profile.profile()
+	if err != nil {
+		return dayActionRequest{}, err
+	}
+
+	data := v.Data
+	if data == "" {
+		data = strings.Join([]string{strconv.Itoa(v.Steps), v.Duration}, ",")
+	}
+	return dayActionRequest{Profile: p, Data: data, Start: v.Start}, nil
+}
+
+// decodeTraining разбирает запрос на расчёт тренировки.
+// Тело запроса text/plain — строка тренировки, данные пользователя и дата передаются в параметрах URL.
+func decodeTraining(r *http.Request) (trainingRequest, error) {
+	isJSON, body, err := readBody(r)
+	if err != nil {
+		return trainingRequest{}, err
+	}
+
+	if !isJSON {
+		values := r.URL.Query()
+		p, err := queryProfile(values)
+		if err != nil {
+			return trainingRequest{}, err
+		}
+		date, err := parseDate(values, "date")
+		if err != nil {
+			return trainingRequest{}, err
+		}
+		return trainingRequest{Profile: p, Data: body, Date: date}, nil
+	}
+
+	var v trainingJSON
+	if err := unmarshal(body, &v); err != nil {
+		return trainingRequest{}, err
+	}
+	p, err := v.Profile.profile()
+	if err != nil {
+		return trainingRequest{}, err
+	}
+
+	data := v.Data
+	if data == "" {
+		data = v.line()
+	}
+	return trainingRequest{Profile: p, Data: data, Date: v.Date}, nil
+}
+
+// line собирает строку тренировки из отдельных полей запроса.
+func (v trainingJSON) line() string {
+	first := strconv.Itoa(v.Steps)
+	if v.DistanceKm != 0 {
+		first = strconv.FormatFloat(v.DistanceKm, 'f', -1, 64) + "km"
+	}
+
+	parts := []string{first, v.Activity, v.Duration}
+	for _, key := range slices.Sorted(maps.Keys(v.Params)) {
+		parts = append(parts, key+"="+v.Params[key])
+	}
+	return strings.Join(parts, ",")
+}
+
+// readBody читает тело запроса и сообщает, передано ли оно в формате JSON.
+// Тело в формате text/plain возвращается без пробелов по краям.
+func readBody(r *http.Request) (bool, string, error) {
+	mediaType := contentText
+	if value := r.Header.Get("Content-Type"); value != "" {
+		var err error
+		if mediaType, _, err = mime.ParseMediaType(value); err != nil {
+			return false, "", &requestError{status: http.StatusUnsupportedMediaType, err: err}
+		}
+	}
+	if mediaType != contentJSON && mediaType != contentText {
+		return false, "", &requestError{
+			status: http.StatusUnsupportedMediaType,
+			err:    fmt.Errorf("неподдерживаемый тип содержимого %q, ожидается %s или %s", mediaType, contentJSON, contentText),
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return false, "", err
+	}
+	if len(body) > maxBodySize {
+		return false, "", &requestError{status: http.StatusRequestEntityTooLarge, err: errors.New("слишком большое тело запроса")}
+	}
+
+	return mediaType == contentJSON, strings.TrimSpace(string(body)), nil
+}
+
+// unmarshal разбирает тело запроса в формате JSON. Неизвестные поля считаются ошибкой.
+func unmarshal(body string, v any) error {
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return badRequest(fmt.Errorf("неверный JSON: %w", err))
+	}
+	return nil
+}
+
+// queryProfile читает данные пользователя из параметров URL weight, height, age, sex, stride и calories.
+func queryProfile(values url.Values) (profile.Profile, error) {
+	var p profileJSON
+
+	for key, dst := range map[string]*float64{"weight": &p.WeightKg, "height": &p.HeightM} {
+		value := values.Get(key)
+		if value == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return profile.Profile{}, badRequest(fmt.Errorf("неверный параметр %s: %w", key, err))
+		}
+		*dst = v
+	}
+	if value := values.Get("age"); value != "" {
+		age, err := strconv.Atoi(value)
+		if err != nil {
+			return profile.Profile{}, badRequest(fmt.Errorf("неверный параметр age: %w", err))
+		}
+		p.Age = age
+	}
+	p.Sex = values.Get("sex")
+	p.Stride = values.Get("stride")
+	p.Calories = values.Get("calories")
+
+	return p.profile()
+}
+
+// parseQuery читает условия выборки истории из параметров URL from, to и activity.
+// Даты указываются в формате ГГГГ-ММ-ДД или в одном из форматов input.TimeLayouts.
+func parseQuery(r *http.Request) (storage.Query, error) {
+	values := r.URL.Query()
+
+	from, err := parseDate(values, "from")
+	if err != nil {
+		return storage.Query{}, err
+	}
+	to, err := parseDate(values, "to")
+	if err != nil {
+		return storage.Query{}, err
+	}
+
+	return storage.Query{From: from, To: to, Activity: values.Get("activity")}, nil
+}
+
+// parseDate читает дату из параметра URL key. Пустой параметр означает нулевую дату.
+func parseDate(values url.Values, key string) (time.Time, error) {
+	value := values.Get(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, nil
+	}
+
+	t, err := input.Time(value, 0)
+	if err != nil {
+		return time.Time{}, badRequest(fmt.Errorf("параметр %s: %w", key, err))
+	}
+	return t, nil
+}
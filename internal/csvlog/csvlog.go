@@ -0,0 +1,226 @@
+package csvlog
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/input"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+// Названия колонок. В заголовке допускаются также русские названия.
+const (
+	columnSteps    = "steps"
+	columnActivity = "activity"
+	columnDuration = "duration"
+	columnDate     = "date"
+)
+
+// columnAliases сопоставляет названия колонок в заголовке с внутренними названиями.
+var columnAliases = map[string]string{
+	"steps":             columnSteps,
+	"шаги":              columnSteps,
+	"activity":          columnActivity,
+	"вид":               columnActivity,
+	"duration":          columnDuration,
+	"продолжительность": columnDuration,
+	"date":              columnDate,
+	"дата":              columnDate,
+}
+
+// dateLayouts — допустимые форматы даты.
+var dateLayouts = []string{time.DateOnly, "2006-01-02 15:04", time.DateTime, time.RFC3339}
+
+// Record — строка CSV, преобразованная в строку тренировки "шаги,вид,продолжительность[,ключ=значение...]".
+type Record struct {
+	Line int       // номер строки в файле, начиная с 1
+	Date time.Time // дата тренировки, нулевое значение — не указана
+	Data string    // строка тренировки для spentcalories.ComputeTraining
+	Err  error     // ошибка разбора строки, например неверная дата
+}
+
+// Read читает тренировки в формате CSV. Первая строка должна содержать заголовок
+// с колонками steps, activity и duration; колонка date необязательна.
+// Остальные непустые колонки передаются как параметры тренировки вида колонка=значение.
+func Read(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("пустой CSV-файл")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать заголовок CSV: %w", err)
+	}
+
+	columns, params, err := parseHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("не удалось прочитать CSV: %w", err)
+			}
+			records = append(records, Record{Line: parseErr.Line, Err: fmt.Errorf("%w: %w", input.ErrInvalidFormat, err)})
+			continue
+		}
+		if isEmpty(row) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		records = append(records, parseRow(row, line, columns, params))
+	}
+
+	return records, nil
+}
+
+// parseHeader возвращает номера обязательных колонок и колонок с параметрами.
+// Параметрами считаются только колонки, объявленные в реестре видов тренировок,
+// остальные колонки, например заметки, пропускаются.
+func parseHeader(header []string) (map[string]int, map[int]string, error) {
+	columns := make(map[string]int)
+	params := make(map[int]string)
+	known := spentcalories.Params()
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if column, ok := columnAliases[name]; ok {
+			columns[column] = i
+		} else if slices.Contains(known, name) {
+			params[i] = name
+		}
+	}
+
+	for _, column := range []string{columnSteps, columnActivity, columnDuration} {
+		if _, ok := columns[column]; !ok {
+			return nil, nil, fmt.Errorf("в заголовке CSV нет колонки %s", column)
+		}
+	}
+
+	return columns, params, nil
+}
+
+func parseRow(row []string, line int, columns map[string]int, params map[int]string) Record {
+	rec := Record{Line: line}
+
+	field := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	if date := field(columnDate); date != "" {
+		d, err := parseDate(date)
+		if err != nil {
+			rec.Err = err
+			return rec
+		}
+		rec.Date = d
+	}
+
+	// Строка тренировки собирается через запятую, поэтому запятая внутри значения
+	// разбила бы его на несколько полей.
+	names := maps.Clone(params)
+	for _, column := range []string{columnSteps, columnActivity, columnDuration} {
+		names[columns[column]] = column
+	}
+	for i, value := range row {
+		if name, ok := names[i]; ok && strings.Contains(value, ",") {
+			rec.Err = &input.ParseError{
+				Field: name,
+				Value: value,
+				Pos:   i + 1,
+				Err:   input.ErrInvalidFormat,
+				Cause: errors.New("значение не должно содержать запятую"),
+			}
+			return rec
+		}
+	}
+
+	parts := []string{field(columnSteps), field(columnActivity), field(columnDuration)}
+	for i, value := range row {
+		name, ok := params[i]
+		if value = strings.TrimSpace(value); ok && value != "" {
+			parts = append(parts, name+"="+value)
+		}
+	}
+	rec.Data = strings.Join(parts, ",")
+
+	return rec
+}
+
+// parseDate разбирает дату тренировки. Дата без часового пояса считается местной, как и время
+// начала дневной активности в input.Time.
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if d, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return d, nil
+		}
+	}
+	return time.Time{}, &input.ParseError{Field: columnDate, Value: value, Err: input.ErrInvalidFormat, Cause: errors.New("неизвестный формат даты")}
+}
+
+func isEmpty(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// header — заголовок CSV с результатами тренировок.
+var header = []string{"date", "activity", "duration_s", "distance_km", "speed_kmh", "calories_kcal"}
+
+// Write записывает результаты тренировок в формате CSV с заголовком.
+// Дата выводится в формате 2006-01-02 15:04 или пустой строкой, если она не указана.
+func Write(w io.Writer, trainings []spentcalories.Training) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, t := range trainings {
+		var date string
+		if !t.Date.IsZero() {
+			date = t.Date.Format("2006-01-02 15:04")
+		}
+
+		row := []string{
+			date,
+			t.Type,
+			strconv.FormatFloat(t.Duration.Seconds(), 'f', -1, 64),
+			strconv.FormatFloat(t.Distance, 'f', 3, 64),
+			strconv.FormatFloat(t.Speed, 'f', 2, 64),
+			strconv.FormatFloat(t.Calories, 'f', 2, 64),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,104 @@
+package csvlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/input"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CSVLogTestSuite struct {
+	suite.Suite
+}
+
+func TestCSVLogSuite(t *testing.T) {
+	suite.Run(t, new(CSVLogTestSuite))
+}
+
+func (suite *CSVLogTestSuite) TestRead() {
+	data := `Date,Activity,Steps,Duration,pool,stroke
+2024-05-01,Бег,6000,1h00m,,
+2024-05-02 07:30,Плавание,40,30m,50,брасс
+
+not a date,Ходьба,3000,30m,,
+,Ходьба,3000,30m,,
+`
+	records, err := Read(strings.NewReader(data))
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), records, 4)
+
+	assert.Equal(suite.T(), Record{Line: 2, Date: time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local), Data: "6000,Бег,1h00m"}, records[0])
+	assert.Equal(suite.T(), Record{Line: 3, Date: time.Date(2024, 5, 2, 7, 30, 0, 0, time.Local), Data: "40,Плавание,30m,pool=50,stroke=брасс"}, records[1])
+
+	assert.Equal(suite.T(), 5, records[2].Line)
+	assert.ErrorIs(suite.T(), records[2].Err, input.ErrInvalidFormat)
+
+	assert.Equal(suite.T(), Record{Line: 6, Data: "3000,Ходьба,30m"}, records[3])
+}
+
+func (suite *CSVLogTestSuite) TestReadDateZone() {
+	records, err := Read(strings.NewReader("date,activity,steps,duration\n2024-05-01 12:00,Бег,6000,1h\n2024-05-01T12:00:00+03:00,Бег,6000,1h\n"))
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), records, 2)
+
+	assert.Equal(suite.T(), time.Local, records[0].Date.Location(), "дата без часового пояса считается местной")
+	assert.True(suite.T(), time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC).Equal(records[1].Date), "указанный часовой пояс сохраняется")
+}
+
+func (suite *CSVLogTestSuite) TestReadRussianHeader() {
+	records, err := Read(strings.NewReader("шаги,вид,продолжительность\n6000,Бег,1h\n"))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []Record{{Line: 2, Data: "6000,Бег,1h"}}, records)
+}
+
+func (suite *CSVLogTestSuite) TestReadUnknownColumns() {
+	records, err := Read(strings.NewReader("date,activity,steps,duration,hr,notes\n2024-05-01,Бег,6000,1h,150,утренняя пробежка\n"))
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), records, 1)
+	assert.NoError(suite.T(), records[0].Err)
+	assert.Equal(suite.T(), "6000,Бег,1h,hr=150", records[0].Data, "колонка без параметра в реестре пропускается")
+}
+
+func (suite *CSVLogTestSuite) TestReadEmbeddedComma() {
+	records, err := Read(strings.NewReader("steps,activity,duration,stroke\n40,Плавание,30m,\"брасс,кроль\"\n"))
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), records, 1)
+
+	var parseErr *input.ParseError
+	if assert.ErrorAs(suite.T(), records[0].Err, &parseErr) {
+		assert.ErrorIs(suite.T(), parseErr, input.ErrInvalidFormat)
+		assert.Equal(suite.T(), "stroke", parseErr.Field)
+		assert.Equal(suite.T(), "брасс,кроль", parseErr.Value)
+		assert.Equal(suite.T(), 4, parseErr.Pos)
+	}
+	assert.Empty(suite.T(), records[0].Data)
+}
+
+func (suite *CSVLogTestSuite) TestReadErrors() {
+	_, err := Read(strings.NewReader(""))
+	assert.Error(suite.T(), err)
+
+	_, err = Read(strings.NewReader("steps,duration\n6000,1h\n"))
+	assert.Error(suite.T(), err, "нет колонки activity")
+}
+
+func (suite *CSVLogTestSuite) TestWrite() {
+	trainings := []spentcalories.Training{
+		{Date: time.Date(2024, 5, 1, 7, 30, 0, 0, time.UTC), Type: "Бег", Duration: time.Hour, Distance: 4.725, Speed: 4.725, Calories: 354.375},
+		{Type: "Ходьба", Duration: 30 * time.Minute, Distance: 2.3625, Speed: 4.725, Calories: 88.59375},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(suite.T(), Write(&buf, trainings))
+
+	want := "date,activity,duration_s,distance_km,speed_kmh,calories_kcal\n" +
+		"2024-05-01 07:30,Бег,3600,4.725,4.72,354.38\n" +
+		",Ходьба,1800,2.362,4.72,88.59\n"
+	assert.Equal(suite.T(), want, buf.String())
+}
@@ -1,20 +1,168 @@
 package daysteps
 
 import (
+	"encoding/json"
+	"log"
 	"time"
-)
 
-const (
-	// Длина одного шага в метрах
-	stepLength = 0.65
-	// Количество метров в одном километре
-	mInKm = 1000
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/input"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+	"github.com/Yandex-Practicum/tracker/internal/units"
 )
 
+// DayAction содержит результаты расчёта дневной активности.
+type DayAction struct {
+	Start    time.Time     // время начала, нулевое значение — не указано
+	Steps    int           // количество шагов
+	Duration time.Duration // продолжительность прогулки
+	Distance float64       // дистанция в км
+	Calories float64       // израсходованные калории
+}
+
+// String возвращает описание активности в формате, который выводит DayActionInfo.
+func (a DayAction) String() string {
+	return a.Text(i18n.Default, units.Metric)
+}
+
+// Text возвращает описание активности на языке lang с дистанцией в системе единиц system.
+func (a DayAction) Text(lang i18n.Lang, system units.System) string {
+	return lang.Sprintf(i18n.DayAction, a.Steps, system.Distance(a.Distance), system.DistanceUnit(lang), a.Calories)
+}
+
+// dayActionJSON — представление дневной активности в JSON. Единицы измерения указаны в названиях полей.
+type dayActionJSON struct {
+	Start        time.Time `json:"start,omitzero"`
+	Steps        int       `json:"steps"`
+	DurationS    float64   `json:"duration_s"`
+	DistanceKm   float64   `json:"distance_km"`
+	CaloriesKcal float64   `json:"calories_kcal"`
+}
+
+// MarshalJSON кодирует дневную активность в JSON с продолжительностью в секундах.
+func (a DayAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dayActionJSON{
+		Start:        a.Start,
+		Steps:        a.Steps,
+		DurationS:    a.Duration.Seconds(),
+		DistanceKm:   a.Distance,
+		CaloriesKcal: a.Calories,
+	})
+}
+
+// UnmarshalJSON декодирует дневную активность, закодированную MarshalJSON.
+func (a *DayAction) UnmarshalJSON(data []byte) error {
+	var v dayActionJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*a = DayAction{
+		Start:    v.Start,
+		Steps:    v.Steps,
+		Duration: time.Duration(v.DurationS * float64(time.Second)),
+		Distance: v.DistanceKm,
+		Calories: v.CaloriesKcal,
+	}
+	return nil
+}
+
 func parsePackage(data string) (int, time.Duration, error) {
-	// TODO: реализовать функцию
+	parts, err := input.Fields(data, 2, 2)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	steps, err := input.Steps(parts[0], 1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	duration, err := input.Duration(parts[1], 2)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return steps, duration, nil
+}
+
+// parseRecord разбирает строку дневной активности "[время,]шаги,продолжительность".
+// Время начала необязательно, например "2024-05-01 07:30,6000,1h00m".
+func parseRecord(data string) (time.Time, int, time.Duration, error) {
+	parts, err := input.Fields(data, 2, 3)
+	if err != nil {
+		return time.Time{}, 0, 0, err
+	}
+	if len(parts) == 2 {
+		steps, duration, err := parsePackage(data)
+		return time.Time{}, steps, duration, err
+	}
+
+	start, err := input.Time(parts[0], 1)
+	if err != nil {
+		return time.Time{}, 0, 0, err
+	}
+
+	steps, err := input.Steps(parts[1], 2)
+	if err != nil {
+		return time.Time{}, 0, 0, err
+	}
+
+	duration, err := input.Duration(parts[2], 3)
+	if err != nil {
+		return time.Time{}, 0, 0, err
+	}
+
+	return start, steps, duration, nil
+}
+
+// ComputeDayAction разбирает строку дневной активности "[время,]шаги,продолжительность"
+// и рассчитывает её показатели для профиля p.
+// Калории считаются по формуле ходьбы из пакета spentcalories. Дистанция считается по модели
+// длины шага из профиля, а если модель не выбрана — по калибровке или модели stride.Default,
+// как и в spentcalories.
+func ComputeDayAction(data string, p profile.Profile) (DayAction, error) {
+	return computeDayAction(data, p, stride.Default)
+}
+
+// computeDayAction рассчитывает дневную активность; def — модель длины шага для дистанции,
+// если в профиле модель не выбрана.
+func computeDayAction(data string, p profile.Profile, def stride.Model) (DayAction, error) {
+	if err := p.Validate(); err != nil {
+		return DayAction{}, err
+	}
+
+	start, steps, duration, err := parseRecord(data)
+	if err != nil {
+		return DayAction{}, err
+	}
+
+	w := spentcalories.Workout{Steps: steps, Duration: duration}
+
+	calories, err := spentcalories.SpentCalories(spentcalories.Walking, w, p)
+	if err != nil {
+		return DayAction{}, err
+	}
+
+	return DayAction{
+		Start:    start,
+		Steps:    steps,
+		Duration: duration,
+		Distance: stride.Distance(stride.Resolve(p, def), p, spentcalories.Walking, steps),
+		Calories: calories,
+	}, nil
 }
 
+// DayActionInfo возвращает текстовое описание дневной активности.
+// При ошибке она записывается в лог, а функция возвращает пустую строку.
+// Для совместимости дистанция считается с постоянной длиной шага stride.DefaultFixed.
 func DayActionInfo(data string, weight, height float64) string {
-	// TODO: реализовать функцию
+	action, err := computeDayAction(data, profile.Profile{Weight: weight, Height: height}, stride.DefaultFixed)
+	if err != nil {
+		log.Println(err)
+		return ""
+	}
+	return action.String()
 }
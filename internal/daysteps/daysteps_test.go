@@ -2,11 +2,18 @@ package daysteps
 
 import (
 	"bytes"
+	"encoding/json"
 	"log"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/input"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+	"github.com/Yandex-Practicum/tracker/internal/units"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -346,3 +353,216 @@ func (suite *DayStepsTestSuite) TestDayActionInfo() {
 		})
 	}
 }
+
+func (suite *DayStepsTestSuite) TestComputeDayAction() {
+	got, err := ComputeDayAction("6000,1h00m", profile.Profile{Weight: 75.0, Height: 1.75})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 6000, got.Steps)
+	assert.Equal(suite.T(), time.Hour, got.Duration)
+	assert.InDelta(suite.T(), 4.725, got.Distance, 1e-9, "по умолчанию длина шага считается по росту, как в spentcalories")
+	assert.InDelta(suite.T(), 177.1875, got.Calories, 1e-9)
+
+	_, err = ComputeDayAction("0,1h00m", profile.Profile{Weight: 75.0, Height: 1.75})
+	assert.Error(suite.T(), err)
+
+	_, err = ComputeDayAction("6000,1h00m", profile.Profile{Height: 1.75})
+	assert.Error(suite.T(), err, "ожидалась ошибка для профиля без веса")
+
+	got, err = ComputeDayAction("6000,1h00m", profile.Profile{Weight: 75.0, Height: 1.75, StrideLength: 0.8})
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 4.8, got.Distance, 1e-9)
+}
+
+func (suite *DayStepsTestSuite) TestCalibratedStride() {
+	p := profile.Profile{Weight: 75.0, Height: 1.75}
+	assert.NoError(suite.T(), p.Calibrate("Ходьба", 700, 1000))
+
+	got, err := ComputeDayAction("6000,1h00m", p)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 4.2, got.Distance, 1e-9)
+
+	assert.NoError(suite.T(), p.Calibrate("Бег", 1000, 1000))
+	got, err = ComputeDayAction("6000,1h00m", p)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 4.2, got.Distance, 1e-9, "калибровка бега не влияет на дневную активность")
+}
+
+// TestStrideModelsAgree проверяет, что daysteps и spentcalories дают одинаковую дистанцию
+// как с выбранной моделью длины шага, так и с моделью по умолчанию stride.Default.
+func (suite *DayStepsTestSuite) TestStrideModelsAgree() {
+	calibrated := profile.Profile{Weight: 75.0, Height: 1.75}
+	assert.NoError(suite.T(), calibrated.Calibrate(spentcalories.Walking, 720, 1000))
+	calibrated.StrideModel = stride.Calibrated{Fallback: stride.DefaultFixed}
+
+	tests := []struct {
+		name     string
+		profile  profile.Profile
+		wantDist float64
+	}{
+		{
+			name:     "постоянная длина шага",
+			profile:  profile.Profile{Weight: 75.0, Height: 1.75, StrideModel: stride.DefaultFixed},
+			wantDist: 3.9,
+		},
+		{
+			name:     "длина шага по росту",
+			profile:  profile.Profile{Weight: 75.0, Height: 1.75, StrideModel: stride.DefaultHeightBased},
+			wantDist: 4.725,
+		},
+		{
+			name:     "калиброванная длина шага",
+			profile:  calibrated,
+			wantDist: 4.32,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			day, err := ComputeDayAction("6000,1h00m", tt.profile)
+			assert.NoError(suite.T(), err)
+
+			training, err := spentcalories.ComputeTraining("6000,Ходьба,1h00m", tt.profile)
+			assert.NoError(suite.T(), err)
+
+			assert.InDelta(suite.T(), tt.wantDist, day.Distance, 1e-9)
+			assert.InDelta(suite.T(), training.Distance, day.Distance, 1e-9, "дистанция в daysteps и spentcalories должна совпадать")
+			assert.InDelta(suite.T(), training.Calories, day.Calories, 1e-9, "калории в daysteps и spentcalories должны совпадать")
+		})
+	}
+
+	suite.Run("модель по умолчанию", func() {
+		p := profile.Profile{Weight: 75.0, Height: 1.75}
+
+		day, err := ComputeDayAction("6000,1h00m", p)
+		assert.NoError(suite.T(), err)
+		training, err := spentcalories.ComputeTraining("6000,Ходьба,1h00m", p)
+		assert.NoError(suite.T(), err)
+
+		assert.InDelta(suite.T(), 4.725, day.Distance, 1e-9)
+		assert.InDelta(suite.T(), training.Distance, day.Distance, 1e-9, "без выбранной модели дистанции совпадают")
+	})
+}
+
+func (suite *DayStepsTestSuite) TestParseErrors() {
+	tests := []struct {
+		input     string
+		wantErr   error
+		wantField string
+		wantPos   int
+	}{
+		{input: "678", wantErr: input.ErrInvalidFormat},
+		{input: "abc,1h30m", wantErr: input.ErrInvalidSteps, wantField: input.FieldSteps, wantPos: 1},
+		{input: "678,1.5d", wantErr: input.ErrInvalidDuration, wantField: input.FieldDuration, wantPos: 2},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.input, func() {
+			_, _, err := parsePackage(tt.input)
+			assert.ErrorIs(suite.T(), err, tt.wantErr)
+
+			var parseErr *input.ParseError
+			if assert.ErrorAs(suite.T(), err, &parseErr) {
+				assert.Equal(suite.T(), tt.wantField, parseErr.Field)
+				assert.Equal(suite.T(), tt.wantPos, parseErr.Pos)
+			}
+		})
+	}
+}
+
+func (suite *DayStepsTestSuite) TestDayActionJSON() {
+	action := DayAction{Steps: 6000, Duration: 90 * time.Minute, Distance: 3.9, Calories: 177.5}
+
+	data, err := json.Marshal(action)
+	assert.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"steps":6000,"duration_s":5400,"distance_km":3.9,"calories_kcal":177.5}`, string(data))
+
+	var got DayAction
+	assert.NoError(suite.T(), json.Unmarshal(data, &got))
+	assert.Equal(suite.T(), action, got)
+}
+
+func (suite *DayStepsTestSuite) TestTimestampedRecord() {
+	got, err := ComputeDayAction("2024-05-01 07:30,6000,1h00m", profile.Profile{Weight: 75.0, Height: 1.75})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), time.Date(2024, 5, 1, 7, 30, 0, 0, time.Local), got.Start)
+	assert.Equal(suite.T(), 6000, got.Steps)
+
+	_, err = ComputeDayAction("12:40:00,6000,1h00m", profile.Profile{Weight: 75.0, Height: 1.75})
+	assert.ErrorIs(suite.T(), err, input.ErrInvalidTime)
+
+	_, err = ComputeDayAction("2024-05-01 07:30,0,1h00m", profile.Profile{Weight: 75.0, Height: 1.75})
+	assert.ErrorIs(suite.T(), err, input.ErrInvalidSteps)
+}
+
+func (suite *DayStepsTestSuite) TestJournal() {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 5, day, hour, minute, 0, 0, time.Local)
+	}
+
+	var j Journal
+
+	assert.NoError(suite.T(), j.Add(DayAction{Start: at(1, 19, 0), Steps: 4000, Duration: 40 * time.Minute, Distance: 2.6, Calories: 100}))
+	assert.NoError(suite.T(), j.Add(DayAction{Start: at(1, 7, 30), Steps: 6000, Duration: time.Hour, Distance: 3.9, Calories: 150}))
+	assert.NoError(suite.T(), j.Add(DayAction{Start: at(1, 23, 30), Steps: 1000, Duration: time.Hour, Distance: 0.65, Calories: 30}))
+	assert.NoError(suite.T(), j.Add(DayAction{Start: at(2, 8, 0), Steps: 2000, Duration: 20 * time.Minute, Distance: 1.3, Calories: 50}))
+
+	assert.ErrorIs(suite.T(), j.Add(DayAction{Start: at(1, 8, 0), Steps: 100, Duration: time.Minute}), ErrOverlap)
+	assert.ErrorIs(suite.T(), j.Add(DayAction{Start: at(2, 0, 10), Steps: 100, Duration: time.Minute}), ErrOverlap, "запись предыдущего дня продолжается после полуночи")
+	assert.ErrorIs(suite.T(), j.Add(DayAction{Steps: 100, Duration: time.Minute}), ErrNoStart)
+	assert.NoError(suite.T(), j.Add(DayAction{Start: at(1, 8, 30), Steps: 100, Duration: time.Minute}), "запись сразу после окончания другой не пересекается с ней")
+
+	assert.Equal(suite.T(), []time.Time{at(1, 0, 0), at(2, 0, 0)}, j.Days())
+
+	day := j.Day(at(1, 12, 0))
+	assert.Len(suite.T(), day, 4)
+	assert.Equal(suite.T(), at(1, 7, 30), day[0].Start, "записи упорядочены по времени начала")
+
+	totals := j.Totals(at(1, 12, 0))
+	assert.Equal(suite.T(), at(1, 0, 0), totals.Date)
+	assert.Equal(suite.T(), 4, totals.Actions)
+	assert.Equal(suite.T(), 11100, totals.Steps)
+	assert.Equal(suite.T(), 2*time.Hour+41*time.Minute, totals.Duration)
+	assert.InDelta(suite.T(), 7.15, totals.Distance, 1e-9)
+	assert.InDelta(suite.T(), 280.0, totals.Calories, 1e-9)
+
+	all := j.AllTotals()
+	assert.Len(suite.T(), all, 2)
+	assert.Equal(suite.T(), 2000, all[1].Steps)
+
+	data, err := json.Marshal(all[1])
+	assert.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"date":"2024-05-02","actions":1,"steps":2000,"duration_s":1200,"distance_km":1.3,"calories_kcal":50}`, string(data))
+}
+
+func (suite *DayStepsTestSuite) TestJournalOverlapAcrossDays() {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 5, day, hour, minute, 0, 0, time.Local)
+	}
+
+	var j Journal
+	assert.NoError(suite.T(), j.Add(DayAction{Start: at(2, 0, 10), Steps: 1000, Duration: 20 * time.Minute}))
+	assert.ErrorIs(suite.T(), j.Add(DayAction{Start: at(1, 23, 30), Steps: 3000, Duration: time.Hour}), ErrOverlap,
+		"запись продолжается в следующий день, где уже есть запись")
+
+	var long Journal
+	assert.NoError(suite.T(), long.Add(DayAction{Start: at(1, 6, 0), Steps: 50000, Duration: 30 * time.Hour}))
+	assert.ErrorIs(suite.T(), long.Add(DayAction{Start: at(2, 11, 0), Steps: 100, Duration: time.Minute}), ErrOverlap,
+		"запись длиннее суток пересекается с записями через день")
+	assert.NoError(suite.T(), long.Add(DayAction{Start: at(2, 12, 0), Steps: 100, Duration: time.Minute}))
+
+	var reversed Journal
+	assert.NoError(suite.T(), reversed.Add(DayAction{Start: at(3, 10, 0), Steps: 100, Duration: time.Minute}))
+	assert.ErrorIs(suite.T(), reversed.Add(DayAction{Start: at(1, 6, 0), Steps: 50000, Duration: 60 * time.Hour}), ErrOverlap,
+		"запись длиннее суток пересекается с уже добавленной записью через день")
+}
+
+func (suite *DayStepsTestSuite) TestLocalization() {
+	action := DayAction{Steps: 678, Duration: 50 * time.Minute, Distance: 0.4407, Calories: 28.7}
+	assert.Equal(suite.T(), action.String(), action.Text(i18n.Russian, units.Metric))
+	assert.Equal(suite.T(), "Steps: 678.\nDistance: 0.44 km.\nCalories burned: 28.70 kcal.\n", action.Text(i18n.English, units.Metric))
+
+	totals := DayTotals{Date: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Actions: 2, Steps: 1000, Duration: time.Hour, Distance: 0.65, Calories: 30}
+	assert.Equal(suite.T(), "2024-05-01: записей 2, шагов 1000, дистанция 0.65 км, 30.00 ккал, активность 1h0m0s.", totals.String())
+	assert.Equal(suite.T(), "2024-05-01: 2 record(s), 1000 steps, distance 0.65 km, 30.00 kcal, active 1h0m0s.", totals.Text(i18n.English, units.Metric))
+}
@@ -0,0 +1,151 @@
+package daysteps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Ошибки журнала активности.
+var (
+	ErrNoStart = errors.New("у записи не указано время начала")
+	ErrOverlap = errors.New("запись пересекается с уже добавленной активностью")
+)
+
+// Journal хранит дневную активность по календарным дням.
+// Нулевое значение готово к использованию.
+type Journal struct {
+	days    map[string][]DayAction // ключ — дата в формате ГГГГ-ММ-ДД
+	longest time.Duration          // наибольшая продолжительность добавленной записи
+}
+
+// DayTotals — итоги активности за календарный день.
+type DayTotals struct {
+	Date     time.Time     // начало дня
+	Actions  int           // количество записей
+	Steps    int           // количество шагов
+	Duration time.Duration // суммарная продолжительность
+	Distance float64       // дистанция в км
+	Calories float64       // израсходованные калории
+}
+
+// Add добавляет запись в журнал. Запись должна содержать время начала
+// и не должна пересекаться по времени с уже добавленными.
+func (j *Journal) Add(a DayAction) error {
+	if a.Start.IsZero() {
+		return ErrNoStart
+	}
+
+	end := a.Start.Add(a.Duration)
+
+	// С записью могут пересекаться записи, начатые в предыдущие дни и продолжающиеся после полуночи,
+	// и записи следующих дней, в которые она сама продолжается.
+	last := dayOf(end)
+	for d := dayOf(a.Start.Add(-j.longest)); !d.After(last); d = d.AddDate(0, 0, 1) {
+		for _, other := range j.days[dayKey(d)] {
+			if a.Start.Before(other.Start.Add(other.Duration)) && other.Start.Before(end) {
+				return fmt.Errorf("%w: %s–%s", ErrOverlap, other.Start.Format("15:04"), other.Start.Add(other.Duration).Format("15:04"))
+			}
+		}
+	}
+
+	if j.days == nil {
+		j.days = make(map[string][]DayAction)
+	}
+
+	key := dayKey(a.Start)
+	actions := append(j.days[key], a)
+	slices.SortFunc(actions, func(x, y DayAction) int { return x.Start.Compare(y.Start) })
+	j.days[key] = actions
+	j.longest = max(j.longest, a.Duration)
+
+	return nil
+}
+
+// Days возвращает дни, за которые есть записи, в порядке возрастания.
+func (j *Journal) Days() []time.Time {
+	keys := make([]string, 0, len(j.days))
+	for key := range j.days {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	days := make([]time.Time, 0, len(keys))
+	for _, key := range keys {
+		days = append(days, dayOf(j.days[key][0].Start))
+	}
+	return days
+}
+
+// Day возвращает записи за день, в который попадает date, упорядоченные по времени начала.
+func (j *Journal) Day(date time.Time) []DayAction {
+	return slices.Clone(j.days[dayKey(date)])
+}
+
+// Totals возвращает итоги за день, в который попадает date.
+func (j *Journal) Totals(date time.Time) DayTotals {
+	totals := DayTotals{Date: dayOf(date)}
+
+	for _, a := range j.days[dayKey(date)] {
+		totals.Actions++
+		totals.Steps += a.Steps
+		totals.Duration += a.Duration
+		totals.Distance += a.Distance
+		totals.Calories += a.Calories
+	}
+	return totals
+}
+
+// AllTotals возвращает итоги по всем дням в порядке возрастания дат.
+func (j *Journal) AllTotals() []DayTotals {
+	var totals []DayTotals
+	for _, day := range j.Days() {
+		totals = append(totals, j.Totals(day))
+	}
+	return totals
+}
+
+// String возвращает описание итогов дня.
+func (t DayTotals) String() string {
+	return t.Text(i18n.Default, units.Metric)
+}
+
+// Text возвращает описание итогов дня на языке lang с дистанцией в системе единиц system.
+func (t DayTotals) Text(lang i18n.Lang, system units.System) string {
+	return lang.Sprintf(i18n.DayTotals, t.Date.Format(time.DateOnly), t.Actions, t.Steps,
+		system.Distance(t.Distance), system.DistanceUnit(lang), t.Calories, t.Duration)
+}
+
+// MarshalJSON кодирует итоги дня в JSON с датой в формате ГГГГ-ММ-ДД и продолжительностью в секундах.
+func (t DayTotals) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Date         string  `json:"date"`
+		Actions      int     `json:"actions"`
+		Steps        int     `json:"steps"`
+		DurationS    float64 `json:"duration_s"`
+		DistanceKm   float64 `json:"distance_km"`
+		CaloriesKcal float64 `json:"calories_kcal"`
+	}{
+		Date:         t.Date.Format(time.DateOnly),
+		Actions:      t.Actions,
+		Steps:        t.Steps,
+		DurationS:    t.Duration.Seconds(),
+		DistanceKm:   t.Distance,
+		CaloriesKcal: t.Calories,
+	})
+}
+
+func dayKey(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
+
+// dayOf возвращает начало календарного дня, в который попадает t, в часовом поясе t.
+func dayOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
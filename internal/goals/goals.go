@@ -0,0 +1,256 @@
+// Package goals рассчитывает выполнение целей по шагам, калориям, дистанции и количеству тренировок.
+package goals
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Metric — показатель, для которого задаётся цель.
+type Metric string
+
+// Показатели целей.
+const (
+	Steps     Metric = "steps"     // количество шагов
+	Calories  Metric = "calories"  // израсходованные калории
+	Distance  Metric = "distance"  // дистанция в км
+	Trainings Metric = "trainings" // количество тренировок без учёта дневной активности
+)
+
+// Goal — цель: значение показателя Metric за период Period не меньше Target.
+type Goal struct {
+	Metric Metric
+	Target float64
+	Period report.Period
+}
+
+// Parse разбирает цель вида "показатель=значение[/период]", например "steps=10000/day"
+// или "trainings=3/week". Период по умолчанию — день.
+func Parse(s string) (Goal, error) {
+	name, value, ok := strings.Cut(strings.TrimSpace(s), "=")
+	if !ok {
+		return Goal{}, fmt.Errorf("неверная цель %q: ожидается показатель=значение[/период]", s)
+	}
+
+	g := Goal{Metric: Metric(strings.ToLower(strings.TrimSpace(name))), Period: report.Day}
+	switch g.Metric {
+	case Steps, Calories, Distance, Trainings:
+	default:
+		return Goal{}, fmt.Errorf("неверная цель %q: неизвестный показатель %q", s, name)
+	}
+
+	value, period, ok := strings.Cut(value, "/")
+	if ok {
+		var err error
+		if g.Period, err = report.ParsePeriod(period); err != nil {
+			return Goal{}, fmt.Errorf("неверная цель %q: %w", s, err)
+		}
+	}
+
+	target, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || target <= 0 {
+		return Goal{}, fmt.Errorf("неверная цель %q: значение должно быть положительным числом", s)
+	}
+	g.Target = target
+
+	return g, nil
+}
+
+// String возвращает цель в формате, который принимает Parse.
+func (g Goal) String() string {
+	return fmt.Sprintf("%s=%s/%s", g.Metric, strconv.FormatFloat(g.Target, 'f', -1, 64), g.Period)
+}
+
+// value возвращает вклад записи в показатель цели.
+func (g Goal) value(e report.Entry) float64 {
+	switch g.Metric {
+	case Steps:
+		if e.StepBased() {
+			return float64(e.Steps)
+		}
+	case Calories:
+		return e.Calories
+	case Distance:
+		return e.Distance
+	case Trainings:
+		if e.Activity != report.DayActivity {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Progress — выполнение цели за один период.
+type Progress struct {
+	Start   time.Time // начало периода, нулевое значение — период записей без даты
+	Value   float64   // значение показателя за период
+	Percent float64   // процент выполнения, может быть больше 100
+	Done    bool      // цель выполнена
+}
+
+// Result — выполнение цели по всем периодам с начала первой записи до конца последней.
+type Result struct {
+	Goal
+	Periods    []Progress // периоды по порядку, включая периоды без записей
+	Streak     int        // количество выполненных подряд периодов, заканчивая последним
+	BestStreak int        // наибольшее количество выполненных подряд периодов
+}
+
+// Current возвращает выполнение цели за последний период.
+func (r Result) Current() Progress {
+	if len(r.Periods) == 0 {
+		return Progress{}
+	}
+	return r.Periods[len(r.Periods)-1]
+}
+
+// Track рассчитывает выполнение целей по записям. Последним считается период последней записи
+// с датой, а не текущая дата. Записи без даты относятся к последнему периоду, а если записей
+// с датой нет — к единственному периоду с нулевым началом.
+func Track(goals []Goal, entries []report.Entry) []Result {
+	results := make([]Result, 0, len(goals))
+	for _, g := range goals {
+		results = append(results, track(g, entries))
+	}
+	return results
+}
+
+// track рассчитывает выполнение цели g. Периоды сравниваются по дате начала, а не по моменту
+// времени, чтобы записи с разными часовыми поясами попадали в один и тот же период.
+func track(g Goal, entries []report.Entry) Result {
+	r := Result{Goal: g}
+
+	values := make(map[string]float64)
+	var first time.Time
+	var firstKey, lastKey string
+	var undated float64
+	hasUndated := false
+	for _, e := range entries {
+		if e.Date.IsZero() {
+			undated += g.value(e)
+			hasUndated = true
+			continue
+		}
+		start := g.Period.Start(e.Date)
+		key := start.Format(time.DateOnly)
+		values[key] += g.value(e)
+		if firstKey == "" || key < firstKey {
+			first, firstKey = start, key
+		}
+		lastKey = max(lastKey, key)
+	}
+
+	if firstKey == "" {
+		if hasUndated {
+			r.add(time.Time{}, undated)
+		}
+		return r
+	}
+
+	for start := first; ; start = g.Period.Next(start) {
+		key := start.Format(time.DateOnly)
+		if key > lastKey {
+			break
+		}
+		value := values[key]
+		if key == lastKey {
+			value += undated
+		}
+		r.add(start, value)
+	}
+
+	return r
+}
+
+// add добавляет период с началом start и значением показателя value и обновляет серии.
+func (r *Result) add(start time.Time, value float64) {
+	p := Progress{
+		Start:   start,
+		Value:   value,
+		Percent: value / r.Target * 100,
+		Done:    value >= r.Target,
+	}
+	r.Periods = append(r.Periods, p)
+
+	if p.Done {
+		r.Streak++
+		r.BestStreak = max(r.BestStreak, r.Streak)
+	} else {
+		r.Streak = 0
+	}
+}
+
+// String возвращает описание выполнения цели за последний период.
+func (r Result) String() string {
+	return r.Text(i18n.Default, units.Metric)
+}
+
+// Text возвращает описание выполнения цели за последний период на языке lang
+// с дистанцией в системе единиц system.
+func (r Result) Text(lang i18n.Lang, system units.System) string {
+	current := r.Current()
+	return lang.Sprintf(i18n.GoalProgress,
+		lang.Sprintf(i18n.Key("goal."+string(r.Metric))), lang.Sprintf(i18n.Key("goal.per."+string(r.Period))),
+		r.format(current.Value, lang, system), r.format(r.Target, lang, system), current.Percent,
+		r.Streak, r.BestStreak)
+}
+
+// format форматирует значение показателя цели.
+func (r Result) format(v float64, lang i18n.Lang, system units.System) string {
+	switch r.Metric {
+	case Distance:
+		return fmt.Sprintf("%.2f %s", system.Distance(v), system.DistanceUnit(lang))
+	case Calories:
+		return fmt.Sprintf("%.0f %s", v, lang.Sprintf(i18n.UnitKcal))
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// MarshalJSON кодирует выполнение цели в JSON с процентом выполнения за последний период.
+func (r Result) MarshalJSON() ([]byte, error) {
+	current := r.Current()
+
+	type progressJSON struct {
+		Start   string  `json:"start,omitempty"`
+		Value   float64 `json:"value"`
+		Percent float64 `json:"percent"`
+		Done    bool    `json:"done"`
+	}
+	periods := make([]progressJSON, 0, len(r.Periods))
+	for _, p := range r.Periods {
+		var start string
+		if !p.Start.IsZero() {
+			start = p.Start.Format(time.DateOnly)
+		}
+		periods = append(periods, progressJSON{start, p.Value, p.Percent, p.Done})
+	}
+
+	return json.Marshal(struct {
+		Metric     Metric         `json:"metric"`
+		Target     float64        `json:"target"`
+		Period     report.Period  `json:"period"`
+		Value      float64        `json:"value"`
+		Percent    float64        `json:"percent"`
+		Done       bool           `json:"done"`
+		Streak     int            `json:"streak"`
+		BestStreak int            `json:"best_streak"`
+		Periods    []progressJSON `json:"periods"`
+	}{
+		Metric:     r.Metric,
+		Target:     r.Target,
+		Period:     r.Period,
+		Value:      current.Value,
+		Percent:    current.Percent,
+		Done:       current.Done,
+		Streak:     r.Streak,
+		BestStreak: r.BestStreak,
+		Periods:    periods,
+	})
+}
@@ -0,0 +1,202 @@
+package goals
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type GoalsTestSuite struct {
+	suite.Suite
+}
+
+func TestGoalsSuite(t *testing.T) {
+	suite.Run(t, new(GoalsTestSuite))
+}
+
+func date(day, hour int) time.Time {
+	return time.Date(2024, 5, day, hour, 0, 0, 0, time.UTC)
+}
+
+// testEntries — записи за 1, 2, 3 и 5 мая 2024 года (среда, четверг, пятница и воскресенье);
+// 4 мая записей нет. Тренировки заданы дистанцией, поэтому шагов у них нет.
+func testEntries() []report.Entry {
+	return []report.Entry{
+		{Date: date(1, 8), Activity: report.DayActivity, Steps: 6000, Distance: 3.9, Calories: 150},
+		{Date: date(1, 19), Activity: report.DayActivity, Steps: 5000, Distance: 3.25, Calories: 120},
+		{Date: date(2, 7), Activity: "Бег", Distance: 10, Calories: 700},
+		{Date: date(2, 9), Activity: report.DayActivity, Steps: 10500, Distance: 6.8, Calories: 260},
+		{Date: date(3, 9), Activity: report.DayActivity, Steps: 12000, Distance: 7.8, Calories: 300},
+		{Date: date(5, 9), Activity: report.DayActivity, Steps: 10000, Distance: 6.5, Calories: 250},
+		{Date: date(5, 18), Activity: "Велосипед", Distance: 20, Calories: 600},
+	}
+}
+
+func (suite *GoalsTestSuite) TestParse() {
+	tests := []struct {
+		name  string
+		input string
+		want  Goal
+	}{
+		{"шаги за день", "steps=10000/day", Goal{Steps, 10000, report.Day}},
+		{"период по умолчанию", "calories=500", Goal{Calories, 500, report.Day}},
+		{"тренировки за неделю", " Trainings = 3/Week ", Goal{Trainings, 3, report.Week}},
+		{"дробная дистанция", "distance=7.5/month", Goal{Distance, 7.5, report.Month}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := Parse(tt.input)
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tt.want, got)
+		})
+	}
+}
+
+func (suite *GoalsTestSuite) TestParseErrors() {
+	for _, input := range []string{"", "steps", "sleep=8/day", "steps=many", "steps=0", "steps=-5", "steps=100/year"} {
+		_, err := Parse(input)
+		assert.Error(suite.T(), err, "цель %q", input)
+	}
+}
+
+func (suite *GoalsTestSuite) TestStringRoundTrip() {
+	g := Goal{Distance, 7.5, report.Week}
+	assert.Equal(suite.T(), "distance=7.5/week", g.String())
+
+	parsed, err := Parse(g.String())
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), g, parsed)
+}
+
+func (suite *GoalsTestSuite) TestTrackSteps() {
+	results := Track([]Goal{{Steps, 10000, report.Day}}, testEntries())
+	require.Len(suite.T(), results, 1)
+	r := results[0]
+
+	require.Len(suite.T(), r.Periods, 5, "дни без записей входят в периоды")
+	assert.Equal(suite.T(), date(4, 0), r.Periods[3].Start)
+	assert.Zero(suite.T(), r.Periods[3].Value)
+	assert.False(suite.T(), r.Periods[3].Done)
+
+	assert.Equal(suite.T(), 11000.0, r.Periods[0].Value)
+	assert.InDelta(suite.T(), 110.0, r.Periods[0].Percent, 1e-9)
+	assert.Equal(suite.T(), 1, r.Streak, "серия прерывается днём без записей")
+	assert.Equal(suite.T(), 3, r.BestStreak)
+
+	current := r.Current()
+	assert.Equal(suite.T(), date(5, 0), current.Start)
+	assert.True(suite.T(), current.Done, "ровно 10000 шагов выполняют цель")
+}
+
+func (suite *GoalsTestSuite) TestTrackCalories() {
+	r := Track([]Goal{{Calories, 500, report.Day}}, testEntries())[0]
+
+	values := make([]float64, 0, len(r.Periods))
+	for _, p := range r.Periods {
+		values = append(values, p.Value)
+	}
+	assert.Equal(suite.T(), []float64{270, 960, 300, 0, 850}, values, "учитываются и тренировки, и дневная активность")
+	assert.Equal(suite.T(), 1, r.Streak)
+	assert.Equal(suite.T(), 1, r.BestStreak)
+}
+
+func (suite *GoalsTestSuite) TestTrackTrainingsPerWeek() {
+	r := Track([]Goal{{Trainings, 2, report.Week}}, testEntries())[0]
+
+	require.Len(suite.T(), r.Periods, 1, "все записи попадают в неделю с 29 апреля")
+	assert.Equal(suite.T(), time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC), r.Periods[0].Start)
+	assert.Equal(suite.T(), 2.0, r.Periods[0].Value, "дневная активность не считается тренировкой")
+	assert.True(suite.T(), r.Periods[0].Done)
+	assert.Equal(suite.T(), 1, r.Streak)
+}
+
+func (suite *GoalsTestSuite) TestTrackStepsNotStepBased() {
+	entries := []report.Entry{
+		{Date: date(1, 8), Activity: report.DayActivity, Steps: 6000},
+		{Date: date(1, 9), Activity: "Бег", Steps: 3000},
+		{Date: date(1, 18), Activity: "Велосипед", Steps: 5000},
+		{Date: date(1, 19), Activity: "Плавание", Steps: 40},
+	}
+
+	r := Track([]Goal{{Steps, 10000, report.Day}}, entries)[0]
+	require.Len(suite.T(), r.Periods, 1)
+	assert.Equal(suite.T(), 9000.0, r.Current().Value, "обороты колеса и бассейны не считаются шагами")
+	assert.False(suite.T(), r.Current().Done)
+}
+
+func (suite *GoalsTestSuite) TestTrackMixedZones() {
+	east := time.FixedZone("UTC+14", 14*60*60)
+	entries := []report.Entry{
+		{Date: date(1, 8), Activity: report.DayActivity, Steps: 6000},
+		{Date: time.Date(2024, 5, 3, 9, 0, 0, 0, east), Activity: report.DayActivity, Steps: 4000},
+		{Activity: report.DayActivity, Steps: 7000},
+	}
+
+	r := Track([]Goal{{Steps, 10000, report.Day}}, entries)[0]
+	require.Len(suite.T(), r.Periods, 3, "последний день определяется по дате, а не по моменту времени")
+	assert.Equal(suite.T(), date(3, 0), r.Current().Start)
+	assert.Equal(suite.T(), 11000.0, r.Current().Value, "записи без даты относятся к последнему дню")
+	assert.Equal(suite.T(), 1, r.Streak)
+}
+
+func (suite *GoalsTestSuite) TestTrackEmpty() {
+	r := Track([]Goal{{Steps, 10000, report.Day}}, nil)[0]
+
+	assert.Empty(suite.T(), r.Periods)
+	assert.Zero(suite.T(), r.Streak)
+	assert.Equal(suite.T(), Progress{}, r.Current())
+}
+
+func (suite *GoalsTestSuite) TestTrackUndated() {
+	undated := []report.Entry{
+		{Activity: report.DayActivity, Steps: 7000, Calories: 200},
+		{Activity: "Бег", Steps: 6000, Calories: 400},
+	}
+
+	r := Track([]Goal{{Steps, 10000, report.Day}}, undated)[0]
+	require.Len(suite.T(), r.Periods, 1, "записи без даты образуют один период")
+	assert.True(suite.T(), r.Current().Start.IsZero())
+	assert.Equal(suite.T(), 13000.0, r.Current().Value, "шаги тренировок учитываются")
+	assert.Equal(suite.T(), 1, r.Streak)
+
+	r = Track([]Goal{{Steps, 10000, report.Day}}, append(testEntries(), undated...))[0]
+	require.Len(suite.T(), r.Periods, 5)
+	assert.Equal(suite.T(), 23000.0, r.Current().Value, "записи без даты относятся к последнему периоду")
+	assert.Equal(suite.T(), 10500.0, r.Periods[1].Value)
+
+	data, err := json.Marshal(Track([]Goal{{Trainings, 1, report.Week}}, undated)[0])
+	require.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{
+		"metric": "trainings", "target": 1, "period": "week",
+		"value": 1, "percent": 100, "done": true, "streak": 1, "best_streak": 1,
+		"periods": [{"value": 1, "percent": 100, "done": true}]
+	}`, string(data))
+}
+
+func (suite *GoalsTestSuite) TestText() {
+	results := Track([]Goal{{Steps, 10000, report.Day}, {Distance, 5, report.Day}}, testEntries())
+
+	assert.Equal(suite.T(), "Шаги за день: 10000 из 10000 (100%), серия 1, лучшая серия 3.", results[0].String())
+	assert.Equal(suite.T(), "Distance per day: 16.47 mi of 3.11 mi (530%), streak 1, best streak 3.",
+		results[1].Text(i18n.English, units.Imperial))
+}
+
+func (suite *GoalsTestSuite) TestMarshalJSON() {
+	r := Track([]Goal{{Trainings, 2, report.Week}}, testEntries())[0]
+
+	data, err := json.Marshal(r)
+	require.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{
+		"metric": "trainings", "target": 2, "period": "week",
+		"value": 2, "percent": 100, "done": true, "streak": 1, "best_streak": 1,
+		"periods": [{"start": "2024-04-29", "value": 2, "percent": 100, "done": true}]
+	}`, string(data))
+}
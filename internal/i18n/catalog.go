@@ -0,0 +1,187 @@
+package i18n
+
+// Ключи сообщений.
+const (
+	// Тренировка: вид, длительность в ч, дистанция и её единица, скорость и её единица, калории.
+	Training Key = "training"
+	// Темп тренировки: время в формате м:сс и отрезок, на который рассчитан темп.
+	TrainingPace Key = "training.pace"
+	// Обозначения единиц дистанции и скорости.
+	UnitKm   Key = "unit.km"
+	UnitMile Key = "unit.mile"
+	UnitKmh  Key = "unit.kmh"
+	UnitMph  Key = "unit.mph"
+
+	UnitKcal  Key = "unit.kcal"
+	UnitSteps Key = "unit.steps"
+
+	// Заголовки колонок таблиц.
+	ColumnDate     Key = "column.date"
+	ColumnStart    Key = "column.start"
+	ColumnActivity Key = "column.activity"
+	ColumnSteps    Key = "column.steps"
+	ColumnDuration Key = "column.duration"
+	ColumnDistance Key = "column.distance"
+	ColumnSpeed    Key = "column.speed"
+	ColumnPace     Key = "column.pace"
+	ColumnCalories Key = "column.calories"
+
+	// Отрезок темпа в километрах и в метрах.
+	PaceKm     Key = "pace.km"
+	PaceMeters Key = "pace.meters"
+
+	// Оценка времени на стандартных дистанциях: список отрезков и название полумарафона.
+	TrainingSplits    Key = "training.splits"
+	SplitHalfMarathon Key = "split.half_marathon"
+
+	// Дневная активность: шаги, дистанция и её единица, калории.
+	DayAction Key = "day_action"
+	// Итоги дня: дата, записей, шагов, дистанция и её единица, калории, время активности.
+	DayTotals Key = "day_totals"
+
+	// Показатели сводки: шагов, дистанция и её единица, калории, время активности.
+	ReportTotals Key = "report.totals"
+	// Подписи периодов сводки с датой начала.
+	ReportDay   Key = "report.day"
+	ReportWeek  Key = "report.week"
+	ReportMonth Key = "report.month"
+	// Строки сводки.
+	ReportTotal      Key = "report.total"
+	ReportAverage    Key = "report.average"
+	ReportBestDay    Key = "report.best_day"
+	ReportByActivity Key = "report.by_activity"
+	ReportActivity   Key = "report.activity"
+	ReportEmpty      Key = "report.empty"
+
+	// Выполнение цели: показатель, период, значение, цель, процент, текущая и лучшая серии.
+	GoalProgress Key = "goal.progress"
+	// Названия показателей и периодов целей.
+	GoalSteps     Key = "goal.steps"
+	GoalCalories  Key = "goal.calories"
+	GoalDistance  Key = "goal.distance"
+	GoalTrainings Key = "goal.trainings"
+	GoalPerDay    Key = "goal.per.day"
+	GoalPerWeek   Key = "goal.per.week"
+	GoalPerMonth  Key = "goal.per.month"
+
+	// Заголовки и подписи вывода трекера.
+	DayActionsTitle Key = "title.day_actions"
+	DayTotalsTitle  Key = "title.day_totals"
+	TrainingsTitle  Key = "title.trainings"
+	GoalsTitle      Key = "title.goals"
+	Start           Key = "start"
+	Rejected        Key = "rejected"
+)
+
+var catalogs = map[Lang]map[Key]string{
+	Russian: {
+		Training:     "Тип тренировки: %s\nДлительность: %.2f ч.\nДистанция: %.2f %s.\nСкорость: %.2f %s\nСожгли калорий: %.2f\n",
+		TrainingPace: "Темп: %s /%s\n",
+		PaceKm:       "км",
+		PaceMeters:   "%g м",
+		UnitKm:       "км",
+		UnitMile:     "ми",
+		UnitKmh:      "км/ч",
+		UnitMph:      "ми/ч",
+		UnitKcal:     "ккал",
+		UnitSteps:    "шагов",
+
+		TrainingSplits:    "Прогноз: %s\n",
+		SplitHalfMarathon: "полумарафон",
+
+		ColumnDate:     "Дата",
+		ColumnStart:    "Начало",
+		ColumnActivity: "Вид",
+		ColumnSteps:    "Шаги",
+		ColumnDuration: "Время",
+		ColumnDistance: "Дистанция",
+		ColumnSpeed:    "Скорость",
+		ColumnPace:     "Темп",
+		ColumnCalories: "Калории, ккал",
+
+		DayAction: "Количество шагов: %d.\nДистанция составила %.2f %s.\nВы сожгли %.2f ккал.\n",
+		DayTotals: "%s: записей %d, шагов %d, дистанция %.2f %s, %.2f ккал, активность %s.",
+
+		ReportTotals:     "шагов %d, дистанция %.2f %s, %.2f ккал, активность %s",
+		ReportDay:        "День %s",
+		ReportWeek:       "Неделя с %s",
+		ReportMonth:      "Месяц %s",
+		ReportTotal:      "Всего: записей %d, %s.\n",
+		ReportAverage:    "В среднем за активный день (%d): %s.\n",
+		ReportBestDay:    "Лучший день: %s, %.2f ккал.\n",
+		ReportByActivity: "По видам активности:\n",
+		ReportActivity:   "  %s: записей %d, %s.\n",
+		ReportEmpty:      "Нет записей с датой для сводки",
+
+		GoalProgress:  "%s %s: %s из %s (%.0f%%), серия %d, лучшая серия %d.",
+		GoalSteps:     "Шаги",
+		GoalCalories:  "Калории",
+		GoalDistance:  "Дистанция",
+		GoalTrainings: "Тренировки",
+		GoalPerDay:    "за день",
+		GoalPerWeek:   "за неделю",
+		GoalPerMonth:  "за месяц",
+
+		DayActionsTitle: "Активность в течение дня",
+		DayTotalsTitle:  "Итоги по дням",
+		TrainingsTitle:  "Журнал тренировок",
+		GoalsTitle:      "Цели",
+		Start:           "Начало: %s.\n",
+		Rejected:        "Отклонено записей: %d\n",
+	},
+	English: {
+		Training:     "Training type: %s\nDuration: %.2f h.\nDistance: %.2f %s.\nSpeed: %.2f %s\nCalories burned: %.2f\n",
+		TrainingPace: "Pace: %s /%s\n",
+		PaceKm:       "km",
+		PaceMeters:   "%g m",
+		UnitKm:       "km",
+		UnitMile:     "mi",
+		UnitKmh:      "km/h",
+		UnitMph:      "mph",
+		UnitKcal:     "kcal",
+		UnitSteps:    "steps",
+
+		TrainingSplits:    "Projected: %s\n",
+		SplitHalfMarathon: "half marathon",
+
+		ColumnDate:     "Date",
+		ColumnStart:    "Start",
+		ColumnActivity: "Activity",
+		ColumnSteps:    "Steps",
+		ColumnDuration: "Time",
+		ColumnDistance: "Distance",
+		ColumnSpeed:    "Speed",
+		ColumnPace:     "Pace",
+		ColumnCalories: "Calories, kcal",
+
+		DayAction: "Steps: %d.\nDistance: %.2f %s.\nCalories burned: %.2f kcal.\n",
+		DayTotals: "%s: %d record(s), %d steps, distance %.2f %s, %.2f kcal, active %s.",
+
+		ReportTotals:     "%d steps, distance %.2f %s, %.2f kcal, active %s",
+		ReportDay:        "Day %s",
+		ReportWeek:       "Week of %s",
+		ReportMonth:      "Month %s",
+		ReportTotal:      "Total: %d record(s), %s.\n",
+		ReportAverage:    "Average per active day (%d): %s.\n",
+		ReportBestDay:    "Best day: %s, %.2f kcal.\n",
+		ReportByActivity: "By activity:\n",
+		ReportActivity:   "  %s: %d record(s), %s.\n",
+		ReportEmpty:      "No dated records for the report",
+
+		GoalProgress:  "%s %s: %s of %s (%.0f%%), streak %d, best streak %d.",
+		GoalSteps:     "Steps",
+		GoalCalories:  "Calories",
+		GoalDistance:  "Distance",
+		GoalTrainings: "Trainings",
+		GoalPerDay:    "per day",
+		GoalPerWeek:   "per week",
+		GoalPerMonth:  "per month",
+
+		DayActionsTitle: "Daily activity",
+		DayTotalsTitle:  "Daily totals",
+		TrainingsTitle:  "Training log",
+		GoalsTitle:      "Goals",
+		Start:           "Start: %s.\n",
+		Rejected:        "Rejected records: %d\n",
+	},
+}
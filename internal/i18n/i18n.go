@@ -0,0 +1,103 @@
+// Package i18n содержит каталоги сообщений для вывода результатов на разных языках.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Lang — язык вывода.
+type Lang string
+
+// Поддерживаемые языки.
+const (
+	Russian Lang = "ru"
+	English Lang = "en"
+)
+
+// Default — язык по умолчанию.
+const Default = Russian
+
+// Parse разбирает обозначение языка: ru, en или локаль вида en_US.UTF-8 и en-US.
+func Parse(s string) (Lang, error) {
+	code := strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(code, "_-."); i >= 0 {
+		code = code[:i]
+	}
+
+	switch lang := Lang(code); lang {
+	case Russian, English:
+		return lang, nil
+	}
+	return "", fmt.Errorf("неподдерживаемый язык: %q", s)
+}
+
+// Key — ключ сообщения в каталоге.
+type Key string
+
+// Sprintf форматирует сообщение key из каталога языка l.
+// Если в каталоге нет сообщения, используется каталог языка по умолчанию.
+func (l Lang) Sprintf(key Key, args ...any) string {
+	format, ok := catalogs[l][key]
+	if !ok {
+		format, ok = catalogs[Default][key]
+	}
+	if !ok {
+		format = string(key)
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+var (
+	activityMu sync.RWMutex
+	// activities — названия видов активности на разных языках по названию на языке по умолчанию.
+	activities = map[Lang]map[string]string{
+		English: {
+			"Бег":                "Run",
+			"Ходьба":             "Walk",
+			"Велосипед":          "Cycling",
+			"Плавание":           "Swim",
+			"Дневная активность": "Daily activity",
+		},
+	}
+)
+
+// Activity возвращает название вида активности name на языке l.
+// Если перевода нет, название возвращается без изменений.
+func (l Lang) Activity(name string) string {
+	activityMu.RLock()
+	defer activityMu.RUnlock()
+
+	if translated, ok := activities[l][name]; ok {
+		return translated
+	}
+	return name
+}
+
+// Canonical возвращает название вида активности на языке по умолчанию по названию на любом
+// из поддерживаемых языков без учёта регистра, например "Бег" для "run".
+func Canonical(name string) (string, bool) {
+	activityMu.RLock()
+	defer activityMu.RUnlock()
+
+	for _, names := range activities {
+		for canonical, translated := range names {
+			if strings.EqualFold(strings.TrimSpace(name), translated) {
+				return canonical, true
+			}
+		}
+	}
+	return "", false
+}
+
+// RegisterActivity добавляет перевод названия вида активности name на язык lang.
+func RegisterActivity(lang Lang, name, translated string) {
+	activityMu.Lock()
+	defer activityMu.Unlock()
+
+	if activities[lang] == nil {
+		activities[lang] = make(map[string]string)
+	}
+	activities[lang][name] = translated
+}
@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type I18nTestSuite struct {
+	suite.Suite
+}
+
+func TestI18nSuite(t *testing.T) {
+	suite.Run(t, new(I18nTestSuite))
+}
+
+func (suite *I18nTestSuite) TestParse() {
+	tests := []struct {
+		input string
+		want  Lang
+	}{
+		{input: "ru", want: Russian},
+		{input: "EN", want: English},
+		{input: "en_US.UTF-8", want: English},
+		{input: "ru-RU", want: Russian},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.input)
+		assert.NoError(suite.T(), err, tt.input)
+		assert.Equal(suite.T(), tt.want, got, tt.input)
+	}
+
+	_, err := Parse("de")
+	assert.Error(suite.T(), err)
+	_, err = Parse("")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *I18nTestSuite) TestCatalogsComplete() {
+	for key := range catalogs[Default] {
+		for lang, catalog := range catalogs {
+			assert.Contains(suite.T(), catalog, key, "в каталоге %s нет сообщения %s", lang, key)
+		}
+	}
+}
+
+func (suite *I18nTestSuite) TestSprintf() {
+	assert.Equal(suite.T(), "Начало: 07:30.\n", Russian.Sprintf(Start, "07:30"))
+	assert.Equal(suite.T(), "Start: 07:30.\n", English.Sprintf(Start, "07:30"))
+	assert.Equal(suite.T(), "Начало: 07:30.\n", Lang("de").Sprintf(Start, "07:30"), "неизвестный язык использует каталог по умолчанию")
+	assert.Equal(suite.T(), "unknown.key", English.Sprintf("unknown.key"))
+}
+
+func (suite *I18nTestSuite) TestActivity() {
+	assert.Equal(suite.T(), "Run", English.Activity("Бег"))
+	assert.Equal(suite.T(), "Бег", Russian.Activity("Бег"))
+	assert.Equal(suite.T(), "Гребля", English.Activity("Гребля"), "без перевода название не меняется")
+
+	canonical, ok := Canonical(" WALK ")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "Ходьба", canonical)
+
+	_, ok = Canonical("Теннис")
+	assert.False(suite.T(), ok)
+
+	RegisterActivity(English, "Гребля", "Rowing")
+	assert.Equal(suite.T(), "Rowing", English.Activity("Гребля"))
+	canonical, ok = Canonical("rowing")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "Гребля", canonical)
+}
@@ -0,0 +1,153 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ошибки разбора входных данных. Проверяются с помощью errors.Is.
+var (
+	ErrInvalidFormat   = errors.New("неверный формат данных")
+	ErrInvalidSteps    = errors.New("неверное количество шагов")
+	ErrInvalidDuration = errors.New("неверная продолжительность")
+	ErrInvalidDistance = errors.New("неверная дистанция")
+	ErrInvalidParam    = errors.New("неверный параметр")
+	ErrInvalidTime     = errors.New("неверное время")
+	ErrUnknownActivity = errors.New("неизвестный тип тренировки")
+)
+
+// Названия полей входной строки.
+const (
+	FieldSteps    = "шаги"
+	FieldActivity = "вид"
+	FieldDuration = "продолжительность"
+	FieldDistance = "дистанция"
+	FieldParam    = "параметр"
+	FieldTime     = "время"
+)
+
+// TimeLayouts — допустимые форматы времени начала записи.
+var TimeLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04", time.DateTime, time.RFC3339}
+
+// ParseError описывает ошибку разбора поля входной строки.
+type ParseError struct {
+	Field string // название поля, пустое для ошибок формата всей строки
+	Value string // исходное значение поля или вся строка
+	Pos   int    // номер поля, начиная с 1; 0 — позиция неизвестна
+	Err   error  // одна из ошибок Err*
+	Cause error  // исходная ошибка, может быть nil
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+
+	b.WriteString(e.Err.Error())
+	switch {
+	case e.Field != "" && e.Pos > 0:
+		fmt.Fprintf(&b, ": поле %d (%s) = %q", e.Pos, e.Field, e.Value)
+	case e.Field != "":
+		fmt.Fprintf(&b, ": %s = %q", e.Field, e.Value)
+	default:
+		fmt.Fprintf(&b, ": %q", e.Value)
+	}
+	if e.Cause != nil {
+		fmt.Fprintf(&b, ": %v", e.Cause)
+	}
+	return b.String()
+}
+
+// Unwrap позволяет проверять как ошибку Err*, так и исходную ошибку.
+func (e *ParseError) Unwrap() []error {
+	if e.Cause == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Err, e.Cause}
+}
+
+// errNotPositive — причина ошибки для нулевых и отрицательных значений.
+var errNotPositive = errors.New("значение должно быть больше нуля")
+
+// Fields разбивает строку на поля, разделённые запятыми, и проверяет их количество.
+// Если max меньше нуля, количество полей сверху не ограничено.
+func Fields(data string, min, max int) ([]string, error) {
+	parts := strings.Split(data, ",")
+	if len(parts) < min || (max >= 0 && len(parts) > max) {
+		return nil, &ParseError{
+			Value: data,
+			Err:   ErrInvalidFormat,
+			Cause: fmt.Errorf("неверное количество полей: %d", len(parts)),
+		}
+	}
+	return parts, nil
+}
+
+// Steps разбирает положительное количество шагов из поля с номером pos.
+func Steps(value string, pos int) (int, error) {
+	steps, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &ParseError{Field: FieldSteps, Value: value, Pos: pos, Err: ErrInvalidSteps, Cause: err}
+	}
+	if steps <= 0 {
+		return 0, &ParseError{Field: FieldSteps, Value: value, Pos: pos, Err: ErrInvalidSteps, Cause: errNotPositive}
+	}
+	return steps, nil
+}
+
+// Duration разбирает положительную продолжительность из поля с номером pos.
+func Duration(value string, pos int) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, &ParseError{Field: FieldDuration, Value: value, Pos: pos, Err: ErrInvalidDuration, Cause: err}
+	}
+	if duration <= 0 {
+		return 0, &ParseError{Field: FieldDuration, Value: value, Pos: pos, Err: ErrInvalidDuration, Cause: errNotPositive}
+	}
+	return duration, nil
+}
+
+// Distance разбирает положительную дистанцию из поля с номером pos.
+func Distance(value string, pos int) (float64, error) {
+	distance, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, &ParseError{Field: FieldDistance, Value: value, Pos: pos, Err: ErrInvalidDistance, Cause: err}
+	}
+	if distance <= 0 {
+		return 0, &ParseError{Field: FieldDistance, Value: value, Pos: pos, Err: ErrInvalidDistance, Cause: errNotPositive}
+	}
+	return distance, nil
+}
+
+// Time разбирает время начала записи из поля с номером pos в одном из форматов TimeLayouts.
+// Время без часового пояса считается местным.
+func Time(value string, pos int) (time.Time, error) {
+	for _, layout := range TimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, &ParseError{Field: FieldTime, Value: value, Pos: pos, Err: ErrInvalidTime, Cause: errors.New("ожидается ГГГГ-ММ-ДД ЧЧ:ММ")}
+}
+
+// Param разбирает поле с номером pos вида ключ=значение.
+func Param(value string, pos int) (string, string, error) {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" || val == "" {
+		return "", "", &ParseError{Field: FieldParam, Value: value, Pos: pos, Err: ErrInvalidParam, Cause: errors.New("ожидается ключ=значение")}
+	}
+	return key, val, nil
+}
+
+// PositiveFloat разбирает положительное числовое значение параметра key.
+func PositiveFloat(key, value string) (float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, &ParseError{Field: key, Value: value, Err: ErrInvalidParam, Cause: err}
+	}
+	if f <= 0 {
+		return 0, &ParseError{Field: key, Value: value, Err: ErrInvalidParam, Cause: errNotPositive}
+	}
+	return f, nil
+}
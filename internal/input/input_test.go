@@ -0,0 +1,101 @@
+package input
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type InputTestSuite struct {
+	suite.Suite
+}
+
+func TestInputSuite(t *testing.T) {
+	suite.Run(t, new(InputTestSuite))
+}
+
+func (suite *InputTestSuite) TestFields() {
+	parts, err := Fields("678,1h30m", 2, 2)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"678", "1h30m"}, parts)
+
+	parts, err = Fields("678,Бег,1h,pool=25,stroke=брасс", 3, -1)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), parts, 5)
+
+	_, err = Fields("678", 2, 2)
+	assert.ErrorIs(suite.T(), err, ErrInvalidFormat)
+
+	_, err = Fields("678,1h30m,extra", 2, 2)
+	assert.ErrorIs(suite.T(), err, ErrInvalidFormat)
+}
+
+func (suite *InputTestSuite) TestSteps() {
+	steps, err := Steps("+12345", 1)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 12345, steps)
+
+	_, err = Steps("abc", 1)
+	assert.ErrorIs(suite.T(), err, ErrInvalidSteps)
+	assert.ErrorIs(suite.T(), err, strconv.ErrSyntax, "исходная ошибка должна быть доступна через errors.Is")
+
+	var parseErr *ParseError
+	_, err = Steps("0", 3)
+	assert.True(suite.T(), errors.As(err, &parseErr))
+	assert.Equal(suite.T(), FieldSteps, parseErr.Field)
+	assert.Equal(suite.T(), "0", parseErr.Value)
+	assert.Equal(suite.T(), 3, parseErr.Pos)
+	assert.Equal(suite.T(), `неверное количество шагов: поле 3 (шаги) = "0": значение должно быть больше нуля`, err.Error())
+}
+
+func (suite *InputTestSuite) TestDuration() {
+	duration, err := Duration("1h30m", 2)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 90*time.Minute, duration)
+
+	for _, value := range []string{"invalid", "0h0m", "-1h", "30"} {
+		_, err := Duration(value, 2)
+		assert.ErrorIs(suite.T(), err, ErrInvalidDuration, "Duration(%q)", value)
+	}
+}
+
+func (suite *InputTestSuite) TestDistance() {
+	distance, err := Distance("25.5", 1)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 25.5, distance)
+
+	_, err = Distance("-1", 1)
+	assert.ErrorIs(suite.T(), err, ErrInvalidDistance)
+}
+
+func (suite *InputTestSuite) TestParam() {
+	key, value, err := Param("pool=50", 4)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "pool", key)
+	assert.Equal(suite.T(), "50", value)
+
+	for _, param := range []string{"extra", "=50", "pool="} {
+		_, _, err := Param(param, 4)
+		assert.ErrorIs(suite.T(), err, ErrInvalidParam, "Param(%q)", param)
+	}
+
+	_, err = PositiveFloat("pool", "0")
+	assert.ErrorIs(suite.T(), err, ErrInvalidParam)
+}
+
+func (suite *InputTestSuite) TestTime() {
+	got, err := Time("2024-05-01 07:30", 1)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), time.Date(2024, 5, 1, 7, 30, 0, 0, time.Local), got)
+
+	got, err = Time("2024-05-01T07:30:00+03:00", 1)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), got.Equal(time.Date(2024, 5, 1, 4, 30, 0, 0, time.UTC)))
+
+	_, err = Time("12:40:00", 1)
+	assert.ErrorIs(suite.T(), err, ErrInvalidTime)
+}
@@ -0,0 +1,50 @@
+package layout
+
+// builtins — тексты встроенных макетов.
+var builtins = map[string]string{
+	Verbose:  verboseLayout,
+	Compact:  compactLayout,
+	Markdown: markdownLayout,
+}
+
+// verboseLayout повторяет вывод String с временем начала дневной активности,
+// а для бега добавляет темп на единицу дистанции и прогноз времени на стандартных дистанциях.
+const verboseLayout = `
+{{- define "trainings"}}{{range .}}{{.Text}}
+{{- if .Splits}}{{msg "training.pace" .Pace .PaceUnit}}{{msg "training.splits" (splits .Splits)}}{{end}}
+{{end}}{{end}}
+
+{{- define "day_actions"}}{{range .}}
+{{- if not .Start.IsZero}}{{msg "start" (.Start.Format "2006-01-02 15:04")}}{{end}}{{.Text}}
+{{end}}{{end}}`
+
+// compactLayout выводит каждую запись одной строкой.
+const compactLayout = `
+{{- define "trainings"}}{{range .}}
+{{- if not .Date.IsZero}}{{.Date.Format "2006-01-02 15:04"}} {{end}}
+{{- .Type}}: {{printf "%.2f" .Distance}} {{.DistanceUnit}}, {{clock .Duration}}, {{printf "%.2f" .Speed}} {{.SpeedUnit}}
+{{- if .Pace}}, {{.Pace}} /{{.PaceUnit}}{{end}}, {{printf "%.0f" .Calories}} {{msg "unit.kcal"}}
+{{end}}{{end}}
+
+{{- define "day_actions"}}{{range .}}
+{{- if not .Start.IsZero}}{{.Start.Format "2006-01-02 15:04"}} {{end}}
+{{- .Steps}} {{msg "unit.steps"}}, {{printf "%.2f" .Distance}} {{.DistanceUnit}}, {{clock .Duration}}, {{printf "%.0f" .Calories}} {{msg "unit.kcal"}}
+{{end}}{{end}}`
+
+// markdownLayout выводит записи таблицей Markdown, после которой идёт пустая строка.
+const markdownLayout = `
+{{- define "trainings"}}{{if .}}
+{{- msg "column.date"}} | {{msg "column.activity"}} | {{msg "column.duration"}} | {{msg "column.distance"}} | {{msg "column.speed"}} | {{msg "column.pace"}} | {{msg "column.calories"}}
+--- | --- | ---: | ---: | ---: | ---: | ---:
+{{range .}}
+{{- if not .Date.IsZero}}{{.Date.Format "2006-01-02 15:04"}}{{end}} | {{.Type}} | {{clock .Duration}} | {{printf "%.2f" .Distance}} {{.DistanceUnit}} | {{printf "%.2f" .Speed}} {{.SpeedUnit}} | {{if .Pace}}{{.Pace}} /{{.PaceUnit}}{{end}} | {{printf "%.2f" .Calories}}
+{{end}}
+{{end}}{{end}}
+
+{{- define "day_actions"}}{{if .}}
+{{- msg "column.start"}} | {{msg "column.steps"}} | {{msg "column.duration"}} | {{msg "column.distance"}} | {{msg "column.calories"}}
+--- | ---: | ---: | ---: | ---:
+{{range .}}
+{{- if not .Start.IsZero}}{{.Start.Format "2006-01-02 15:04"}}{{end}} | {{.Steps}} | {{clock .Duration}} | {{printf "%.2f" .Distance}} {{.DistanceUnit}} | {{printf "%.2f" .Calories}}
+{{end}}
+{{end}}{{end}}`
@@ -0,0 +1,205 @@
+// Package layout выводит результаты тренировок и дневной активности по шаблонам text/template.
+//
+// Шаблон — набор из двух именованных шаблонов: "trainings" выполняется для списка []Training,
+// "day_actions" — для списка []DayAction. Значения в списках уже переведены на язык вывода
+// и пересчитаны в выбранную систему единиц. В шаблонах доступны функции:
+//
+//	msg   — сообщение из каталога пакета i18n на языке вывода, например {{msg "unit.kcal"}};
+//	clock — продолжительность в формате ч:мм.
+package layout
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Названия шаблонов в наборе.
+const (
+	TrainingsTemplate  = "trainings"
+	DayActionsTemplate = "day_actions"
+)
+
+// Встроенные макеты.
+const (
+	Compact  = "compact"  // одна строка на запись
+	Verbose  = "verbose"  // подробное описание, как в String
+	Markdown = "markdown" // таблица Markdown
+)
+
+// Training — данные тренировки для шаблона.
+type Training struct {
+	Date         time.Time     // дата, нулевое значение — не указана
+	Type         string        // вид тренировки на языке вывода
+	Duration     time.Duration // продолжительность
+	Distance     float64       // дистанция в единицах DistanceUnit
+	Speed        float64       // средняя скорость в единицах SpeedUnit
+	Calories     float64       // израсходованные калории
+	Pace         string        // темп в формате м:сс, пустой — не рассчитывается
+	PaceUnit     string        // отрезок, на который рассчитан темп, например "100 м"; темп плавания всегда метрический
+	Splits       []Split       // оценка времени на стандартных дистанциях, пустая — не рассчитывается
+	DistanceUnit string
+	SpeedUnit    string
+	Text         string // описание в формате Training.Text
+}
+
+// Split — оценка времени на стандартной дистанции для шаблона.
+type Split struct {
+	Label string // название дистанции на языке вывода, например "5 км"
+	Time  string // время в формате м:сс или ч:мм:сс
+}
+
+// String возвращает оценку в виде "5 км 27:40".
+func (s Split) String() string {
+	return s.Label + " " + s.Time
+}
+
+// DayAction — данные дневной активности для шаблона.
+type DayAction struct {
+	Start        time.Time     // время начала, нулевое значение — не указано
+	Steps        int           // количество шагов
+	Duration     time.Duration // продолжительность
+	Distance     float64       // дистанция в единицах DistanceUnit
+	Calories     float64       // израсходованные калории
+	DistanceUnit string
+	Text         string // описание в формате DayAction.Text
+}
+
+// Layout — набор шаблонов для вывода результатов. Layout можно использовать из нескольких горутин.
+type Layout struct {
+	tmpl *template.Template
+}
+
+// New разбирает набор шаблонов text. В наборе должен быть хотя бы один из шаблонов
+// "trainings" и "day_actions", определённых через {{define}}.
+func New(text string) (*Layout, error) {
+	tmpl, err := template.New("layout").Funcs(funcs(i18n.Default)).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("неверный шаблон: %w", err)
+	}
+	if tmpl.Lookup(TrainingsTemplate) == nil && tmpl.Lookup(DayActionsTemplate) == nil {
+		return nil, fmt.Errorf("в шаблоне нет ни %q, ни %q", TrainingsTemplate, DayActionsTemplate)
+	}
+	return &Layout{tmpl: tmpl}, nil
+}
+
+// Builtin возвращает встроенный макет: compact, verbose или markdown.
+func Builtin(name string) (*Layout, error) {
+	text, ok := builtins[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("неизвестный макет %q, доступны: %s", name, strings.Join(Builtins(), ", "))
+	}
+	return New(text)
+}
+
+// Builtins возвращает отсортированный список названий встроенных макетов.
+func Builtins() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Trainings выводит тренировки на языке lang в системе единиц system.
+func (l *Layout) Trainings(w io.Writer, lang i18n.Lang, system units.System, trainings []spentcalories.Training) error {
+	views := make([]Training, 0, len(trainings))
+	for _, t := range trainings {
+		views = append(views, newTraining(t, lang, system))
+	}
+	return l.execute(w, lang, TrainingsTemplate, views)
+}
+
+// DayActions выводит дневную активность на языке lang в системе единиц system.
+func (l *Layout) DayActions(w io.Writer, lang i18n.Lang, system units.System, actions []daysteps.DayAction) error {
+	views := make([]DayAction, 0, len(actions))
+	for _, a := range actions {
+		views = append(views, newDayAction(a, lang, system))
+	}
+	return l.execute(w, lang, DayActionsTemplate, views)
+}
+
+// execute выполняет шаблон name с функциями для языка lang.
+func (l *Layout) execute(w io.Writer, lang i18n.Lang, name string, data any) error {
+	if l.tmpl.Lookup(name) == nil {
+		return fmt.Errorf("в шаблоне нет %q", name)
+	}
+
+	tmpl, err := l.tmpl.Clone()
+	if err != nil {
+		return err
+	}
+	return tmpl.Funcs(funcs(lang)).ExecuteTemplate(w, name, data)
+}
+
+// funcs возвращает функции, доступные в шаблонах, для языка lang.
+func funcs(lang i18n.Lang) template.FuncMap {
+	return template.FuncMap{
+		"msg": func(key string, args ...any) string {
+			return lang.Sprintf(i18n.Key(key), args...)
+		},
+		"clock":  clock,
+		"splits": joinSplits,
+	}
+}
+
+// joinSplits перечисляет оценки времени через запятую.
+func joinSplits(splits []Split) string {
+	parts := make([]string, 0, len(splits))
+	for _, s := range splits {
+		parts = append(parts, s.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// clock форматирует продолжительность в виде ч:мм.
+func clock(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+func newTraining(t spentcalories.Training, lang i18n.Lang, system units.System) Training {
+	v := Training{
+		Date:         t.Date,
+		Type:         lang.Activity(t.Type),
+		Duration:     t.Duration,
+		Distance:     system.Distance(t.Distance),
+		Speed:        system.Speed(t.Speed),
+		Calories:     t.Calories,
+		DistanceUnit: system.DistanceUnit(lang),
+		SpeedUnit:    system.SpeedUnit(lang),
+		Text:         t.Text(lang, system),
+	}
+	switch {
+	case t.PaceDistance == 1:
+		// темп на единицу дистанции выбранной системы: на километр или на милю
+		v.Pace, v.PaceUnit = spentcalories.FormatPace(system.Pace(t.Pace)), system.DistanceUnit(lang)
+	case t.PaceDistance > 0:
+		v.Pace, v.PaceUnit = spentcalories.FormatPace(t.Pace), spentcalories.PaceUnit(lang, t.PaceDistance)
+	}
+	for _, s := range t.Splits {
+		v.Splits = append(v.Splits, Split{Label: s.Label(lang), Time: spentcalories.FormatTime(s.Time)})
+	}
+	return v
+}
+
+func newDayAction(a daysteps.DayAction, lang i18n.Lang, system units.System) DayAction {
+	return DayAction{
+		Start:        a.Start,
+		Steps:        a.Steps,
+		Duration:     a.Duration,
+		Distance:     system.Distance(a.Distance),
+		Calories:     a.Calories,
+		DistanceUnit: system.DistanceUnit(lang),
+		Text:         a.Text(lang, system),
+	}
+}
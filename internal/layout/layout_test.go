@@ -0,0 +1,165 @@
+package layout
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type LayoutTestSuite struct {
+	suite.Suite
+}
+
+func TestLayoutSuite(t *testing.T) {
+	suite.Run(t, new(LayoutTestSuite))
+}
+
+func testTrainings() []spentcalories.Training {
+	return []spentcalories.Training{
+		{Date: time.Date(2024, 5, 1, 7, 30, 0, 0, time.UTC), Type: spentcalories.Running, Duration: 40 * time.Minute, Distance: 4.725, Speed: 7.0875, Calories: 354.375},
+		{Type: spentcalories.Swimming, Duration: 30 * time.Minute, Distance: 1, Speed: 2, Calories: 217.5, Pace: 3 * time.Minute, PaceDistance: 0.1},
+	}
+}
+
+func testDayActions() []daysteps.DayAction {
+	return []daysteps.DayAction{
+		{Start: time.Date(2024, 5, 1, 7, 30, 0, 0, time.UTC), Steps: 4200, Duration: 45 * time.Minute, Distance: 2.73, Calories: 124.03},
+		{Steps: 1500, Duration: 20 * time.Minute, Distance: 0.975, Calories: 44.3},
+	}
+}
+
+func (suite *LayoutTestSuite) render(l *Layout, lang i18n.Lang, system units.System) (string, string) {
+	var trainings, actions bytes.Buffer
+	require.NoError(suite.T(), l.Trainings(&trainings, lang, system, testTrainings()))
+	require.NoError(suite.T(), l.DayActions(&actions, lang, system, testDayActions()))
+	return trainings.String(), actions.String()
+}
+
+func (suite *LayoutTestSuite) TestVerbose() {
+	l, err := Builtin(Verbose)
+	require.NoError(suite.T(), err)
+
+	trainings, actions := suite.render(l, i18n.Russian, units.Metric)
+
+	var want strings.Builder
+	for _, t := range testTrainings() {
+		want.WriteString(t.String() + "\n")
+	}
+	assert.Equal(suite.T(), want.String(), trainings, "подробный макет совпадает с String")
+
+	a := testDayActions()
+	assert.Equal(suite.T(), "Начало: 2024-05-01 07:30.\n"+a[0].String()+"\n"+a[1].String()+"\n", actions)
+}
+
+func (suite *LayoutTestSuite) TestSplits() {
+	run := spentcalories.Training{Type: spentcalories.Running, Duration: 30 * time.Minute, Distance: 6, Speed: 12, Calories: 400}
+	run.Pace, run.PaceDistance = 5*time.Minute, 1
+	run.Splits = spentcalories.EstimateSplits(run.Distance, run.Duration)
+
+	verbose, err := Builtin(Verbose)
+	require.NoError(suite.T(), err)
+	var b bytes.Buffer
+	require.NoError(suite.T(), verbose.Trainings(&b, i18n.Russian, units.Metric, []spentcalories.Training{run}))
+	assert.Equal(suite.T(),
+		run.String()+"Темп: 5:00 /км\nПрогноз: 1 км 5:00, 5 км 25:00, 10 км 50:00, полумарафон 1:45:29\n\n",
+		b.String(), "для бега подробный макет добавляет темп и прогноз")
+
+	compact, err := Builtin(Compact)
+	require.NoError(suite.T(), err)
+	b.Reset()
+	require.NoError(suite.T(), compact.Trainings(&b, i18n.English, units.Imperial, []spentcalories.Training{run}))
+	assert.Equal(suite.T(), "Run: 3.73 mi, 0:30, 7.46 mph, 8:03 /mi, 400 kcal\n", b.String(), "темп на милю в имперской системе")
+}
+
+func (suite *LayoutTestSuite) TestCompact() {
+	l, err := Builtin("Compact")
+	require.NoError(suite.T(), err)
+
+	trainings, actions := suite.render(l, i18n.English, units.Metric)
+	assert.Equal(suite.T(),
+		"2024-05-01 07:30 Run: 4.72 km, 0:40, 7.09 km/h, 354 kcal\n"+
+			"Swim: 1.00 km, 0:30, 2.00 km/h, 3:00 /100 m, 218 kcal\n",
+		trainings)
+	assert.Equal(suite.T(),
+		"2024-05-01 07:30 4200 steps, 2.73 km, 0:45, 124 kcal\n"+
+			"1500 steps, 0.97 km, 0:20, 44 kcal\n",
+		actions)
+}
+
+func (suite *LayoutTestSuite) TestMarkdown() {
+	l, err := Builtin(Markdown)
+	require.NoError(suite.T(), err)
+
+	// Темп плавания остаётся на 100 м и в имперской системе: длина бассейна задаётся в метрах.
+	trainings, actions := suite.render(l, i18n.Russian, units.Imperial)
+	assert.Equal(suite.T(),
+		"Дата | Вид | Время | Дистанция | Скорость | Темп | Калории, ккал\n"+
+			"--- | --- | ---: | ---: | ---: | ---: | ---:\n"+
+			"2024-05-01 07:30 | Бег | 0:40 | 2.94 ми | 4.40 ми/ч |  | 354.38\n"+
+			" | Плавание | 0:30 | 0.62 ми | 1.24 ми/ч | 3:00 /100 м | 217.50\n\n",
+		trainings)
+	assert.True(suite.T(), strings.HasPrefix(actions, "Начало | Шаги | Время | Дистанция | Калории, ккал\n"))
+
+	var empty bytes.Buffer
+	require.NoError(suite.T(), l.Trainings(&empty, i18n.Russian, units.Metric, nil))
+	assert.Empty(suite.T(), empty.String(), "для пустого списка таблица не выводится")
+}
+
+func (suite *LayoutTestSuite) TestCustomTemplate() {
+	l, err := New(`{{define "trainings"}}{{range .}}*{{.Type}}* {{printf "%.1f" .Distance}} {{.DistanceUnit}} — {{msg "unit.kcal"}}: {{printf "%.0f" .Calories}}
+{{end}}{{end}}`)
+	require.NoError(suite.T(), err)
+
+	var b bytes.Buffer
+	require.NoError(suite.T(), l.Trainings(&b, i18n.English, units.Metric, testTrainings()[:1]))
+	assert.Equal(suite.T(), "*Run* 4.7 km — kcal: 354\n", b.String())
+
+	err = l.DayActions(&b, i18n.English, units.Metric, testDayActions())
+	assert.Error(suite.T(), err, "в шаблоне нет day_actions")
+}
+
+func (suite *LayoutTestSuite) TestErrors() {
+	_, err := Builtin("html")
+	assert.Error(suite.T(), err)
+
+	_, err = New(`{{define "trainings"}}{{range .}}`)
+	assert.Error(suite.T(), err, "ошибка разбора")
+
+	_, err = New(`{{.Type}}`)
+	assert.Error(suite.T(), err, "нет именованных шаблонов")
+
+	l, err := New(`{{define "trainings"}}{{.Missing}}{{end}}`)
+	require.NoError(suite.T(), err)
+	assert.Error(suite.T(), l.Trainings(&bytes.Buffer{}, i18n.Russian, units.Metric, testTrainings()))
+}
+
+func (suite *LayoutTestSuite) TestConcurrentLanguages() {
+	l, err := Builtin(Compact)
+	require.NoError(suite.T(), err)
+
+	var wg sync.WaitGroup
+	for _, lang := range []i18n.Lang{i18n.Russian, i18n.English, i18n.Russian, i18n.English} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var b bytes.Buffer
+			assert.NoError(suite.T(), l.DayActions(&b, lang, units.Metric, testDayActions()))
+			assert.Contains(suite.T(), b.String(), lang.Sprintf(i18n.UnitKcal))
+		}()
+	}
+	wg.Wait()
+}
+
+func (suite *LayoutTestSuite) TestBuiltins() {
+	assert.Equal(suite.T(), []string{Compact, Markdown, Verbose}, Builtins())
+}
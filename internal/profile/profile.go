@@ -0,0 +1,130 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Sex — пол пользователя.
+type Sex string
+
+// Возможные значения пола. Пустое значение означает, что пол не указан.
+const (
+	Male   Sex = "male"
+	Female Sex = "female"
+)
+
+// StrideModel рассчитывает длину шага в м для вида активности.
+// Реализации находятся в пакете stride.
+type StrideModel interface {
+	StrideLength(p Profile, activity string) float64
+}
+
+// CalorieModel рассчитывает калории для вида активности по дистанции в км и продолжительности.
+// Реализации находятся в пакете spentcalories.
+type CalorieModel interface {
+	Calories(p Profile, activity string, distance float64, duration time.Duration) (float64, error)
+}
+
+// Profile содержит данные пользователя, необходимые для расчётов.
+type Profile struct {
+	Weight           float64 // вес в кг
+	Height           float64 // рост в м
+	Age              int     // возраст в годах, 0 — не указан
+	Sex              Sex     // пол, пустое значение — не указан
+	StrideLength     float64 // измеренная длина шага в м, 0 — не указана
+	RestingHeartRate int     // пульс в покое в уд/мин, 0 — не указан
+
+	// Strides — откалиброванная длина шага в м по видам активности.
+	// Ключ — название вида активности в нижнем регистре.
+	Strides map[string]float64
+
+	// StrideModel — выбранная модель длины шага.
+	// Если она не задана, каждый пакет использует собственную модель по умолчанию.
+	StrideModel StrideModel
+
+	// CalorieModel — выбранная модель расчёта калорий для бега и ходьбы.
+	// Если она не задана, используется исходная формула пакета spentcalories.
+	CalorieModel CalorieModel
+}
+
+// maxHeight — наибольший правдоподобный рост в м. Больший рост обычно означает,
+// что он указан не в тех единицах, например в сантиметрах или футах вместо метров.
+const maxHeight = 2.75
+
+// Validate проверяет корректность данных профиля.
+func (p Profile) Validate() error {
+	switch {
+	case p.Weight <= 0:
+		return errors.New("вес должен быть больше нуля")
+	case p.Height <= 0:
+		return errors.New("рост должен быть больше нуля")
+	case p.Height > maxHeight:
+		return fmt.Errorf("рост %.2f м больше %.1f м: проверьте единицы измерения", p.Height, maxHeight)
+	case p.Age < 0:
+		return errors.New("возраст не может быть отрицательным")
+	case p.Sex != "" && p.Sex != Male && p.Sex != Female:
+		return fmt.Errorf("неизвестный пол: %q", p.Sex)
+	case p.StrideLength < 0:
+		return errors.New("длина шага не может быть отрицательной")
+	case p.RestingHeartRate < 0:
+		return errors.New("пульс в покое не может быть отрицательным")
+	}
+	for activity, stride := range p.Strides {
+		if stride <= 0 {
+			return fmt.Errorf("откалиброванная длина шага для %q должна быть больше нуля", activity)
+		}
+	}
+	return nil
+}
+
+// CalibratedStride возвращает откалиброванную длину шага в м для вида активности.
+func (p Profile) CalibratedStride(activity string) (float64, bool) {
+	stride, ok := p.Strides[strideKey(activity)]
+	return stride, ok
+}
+
+// Calibrate рассчитывает длину шага по известной дистанции в м и количеству шагов на ней
+// и сохраняет её для вида активности.
+func (p *Profile) Calibrate(activity string, distance float64, steps int) error {
+	stride, err := StrideFromDistance(distance, steps)
+	if err != nil {
+		return err
+	}
+
+	if p.Strides == nil {
+		p.Strides = make(map[string]float64)
+	}
+	p.Strides[strideKey(activity)] = stride
+	return nil
+}
+
+// StrideFromDistance возвращает длину шага в м для дистанции distance в м, пройденной за steps шагов.
+func StrideFromDistance(distance float64, steps int) (float64, error) {
+	if distance <= 0 {
+		return 0, errors.New("дистанция калибровки должна быть больше нуля")
+	}
+	if steps <= 0 {
+		return 0, errors.New("количество шагов для калибровки должно быть больше нуля")
+	}
+	return distance / float64(steps), nil
+}
+
+func strideKey(activity string) string {
+	return strings.ToLower(strings.TrimSpace(activity))
+}
+
+// ParseSex разбирает обозначение пола: male/female, m/f, м/ж, муж/жен.
+func ParseSex(s string) (Sex, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return "", nil
+	case "male", "m", "м", "муж":
+		return Male, nil
+	case "female", "f", "ж", "жен":
+		return Female, nil
+	}
+	return "", fmt.Errorf("неизвестный пол: %q", s)
+}
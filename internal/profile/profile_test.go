@@ -0,0 +1,118 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProfileTestSuite struct {
+	suite.Suite
+}
+
+func TestProfileSuite(t *testing.T) {
+	suite.Run(t, new(ProfileTestSuite))
+}
+
+func (suite *ProfileTestSuite) TestValidate() {
+	tests := []struct {
+		name    string
+		profile Profile
+		wantErr bool
+	}{
+		{
+			name:    "только вес и рост",
+			profile: Profile{Weight: 75, Height: 1.75},
+		},
+		{
+			name:    "полный профиль",
+			profile: Profile{Weight: 60, Height: 1.65, Age: 30, Sex: Female, StrideLength: 0.7, RestingHeartRate: 60},
+		},
+		{
+			name:    "нулевой вес",
+			profile: Profile{Height: 1.75},
+			wantErr: true,
+		},
+		{
+			name:    "нулевой рост",
+			profile: Profile{Weight: 75},
+			wantErr: true,
+		},
+		{
+			name:    "рост в сантиметрах",
+			profile: Profile{Weight: 75, Height: 175},
+			wantErr: true,
+		},
+		{
+			name:    "рост выше правдоподобного",
+			profile: Profile{Weight: 75, Height: 21.6},
+			wantErr: true,
+		},
+		{
+			name:    "отрицательный возраст",
+			profile: Profile{Weight: 75, Height: 1.75, Age: -1},
+			wantErr: true,
+		},
+		{
+			name:    "неизвестный пол",
+			profile: Profile{Weight: 75, Height: 1.75, Sex: "x"},
+			wantErr: true,
+		},
+		{
+			name:    "отрицательная длина шага",
+			profile: Profile{Weight: 75, Height: 1.75, StrideLength: -0.5},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			err := tt.profile.Validate()
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+			} else {
+				assert.NoError(suite.T(), err)
+			}
+		})
+	}
+}
+
+func (suite *ProfileTestSuite) TestCalibrate() {
+	p := Profile{Weight: 75, Height: 1.75}
+	assert.NoError(suite.T(), p.Calibrate("Бег", 1000, 1250))
+	assert.NoError(suite.T(), p.Calibrate("Ходьба", 400, 625))
+	assert.NoError(suite.T(), p.Validate())
+
+	stride, ok := p.CalibratedStride("бег")
+	assert.True(suite.T(), ok, "калибровка без учёта регистра")
+	assert.Equal(suite.T(), 0.8, stride)
+
+	stride, ok = p.CalibratedStride("Ходьба")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), 0.64, stride)
+
+	_, ok = p.CalibratedStride("Велосипед")
+	assert.False(suite.T(), ok)
+}
+
+func (suite *ProfileTestSuite) TestCalibrateErrors() {
+	var p Profile
+	assert.Error(suite.T(), p.Calibrate("Бег", 0, 100))
+	assert.Error(suite.T(), p.Calibrate("Бег", 100, 0))
+	assert.Empty(suite.T(), p.Strides)
+
+	p = Profile{Weight: 75, Height: 1.75, Strides: map[string]float64{"бег": 0}}
+	assert.Error(suite.T(), p.Validate())
+}
+
+func (suite *ProfileTestSuite) TestParseSex() {
+	for input, want := range map[string]Sex{"male": Male, "М": Male, "f": Female, "жен": Female, "": ""} {
+		got, err := ParseSex(input)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), want, got, "ParseSex(%q)", input)
+	}
+
+	_, err := ParseSex("other")
+	assert.Error(suite.T(), err)
+}
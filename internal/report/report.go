@@ -0,0 +1,329 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// DayActivity — вид активности для записей дневной активности.
+const DayActivity = "Дневная активность"
+
+// Period — период агрегации.
+type Period string
+
+// Периоды агрегации. Неделя начинается с понедельника.
+const (
+	Day   Period = "day"
+	Week  Period = "week"
+	Month Period = "month"
+)
+
+// ParsePeriod разбирает название периода: day, week или month.
+func ParsePeriod(s string) (Period, error) {
+	switch p := Period(strings.ToLower(strings.TrimSpace(s))); p {
+	case Day, Week, Month:
+		return p, nil
+	}
+	return "", fmt.Errorf("неизвестный период отчёта: %q", s)
+}
+
+// Start возвращает начало периода, в который попадает t.
+func (p Period) Start(t time.Time) time.Time {
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+
+	switch p {
+	case Week:
+		offset := (int(day.Weekday()) + 6) % 7 // дней с понедельника
+		return day.AddDate(0, 0, -offset)
+	case Month:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	}
+	return day
+}
+
+// Next возвращает начало периода, который следует за периодом с началом start.
+func (p Period) Next(start time.Time) time.Time {
+	switch p {
+	case Week:
+		return start.AddDate(0, 0, 7)
+	case Month:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// label возвращает подпись периода, который начинается в start, на языке lang.
+func (p Period) label(lang i18n.Lang, start time.Time) string {
+	switch p {
+	case Week:
+		return lang.Sprintf(i18n.ReportWeek, start.Format(time.DateOnly))
+	case Month:
+		return lang.Sprintf(i18n.ReportMonth, start.Format("2006-01"))
+	}
+	return lang.Sprintf(i18n.ReportDay, start.Format(time.DateOnly))
+}
+
+// Entry — запись активности для отчёта.
+type Entry struct {
+	Date     time.Time     // время начала
+	Activity string        // вид активности
+	Steps    int           // количество шагов, если оно известно
+	Duration time.Duration // продолжительность
+	Distance float64       // дистанция в км
+	Calories float64       // израсходованные калории
+}
+
+// entryJSON — представление записи в JSON. Единицы измерения указаны в названиях полей.
+type entryJSON struct {
+	Date         time.Time `json:"date,omitzero"`
+	Activity     string    `json:"activity"`
+	Steps        int       `json:"steps,omitempty"`
+	DurationS    float64   `json:"duration_s"`
+	DistanceKm   float64   `json:"distance_km"`
+	CaloriesKcal float64   `json:"calories_kcal"`
+}
+
+// MarshalJSON кодирует запись в JSON с продолжительностью в секундах.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(entryJSON{
+		Date:         e.Date,
+		Activity:     e.Activity,
+		Steps:        e.Steps,
+		DurationS:    e.Duration.Seconds(),
+		DistanceKm:   e.Distance,
+		CaloriesKcal: e.Calories,
+	})
+}
+
+// UnmarshalJSON декодирует запись, закодированную MarshalJSON.
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	var v entryJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*e = Entry{
+		Date:     v.Date,
+		Activity: v.Activity,
+		Steps:    v.Steps,
+		Duration: time.Duration(v.DurationS * float64(time.Second)),
+		Distance: v.DistanceKm,
+		Calories: v.CaloriesKcal,
+	}
+	return nil
+}
+
+// StepBased сообщает, учитываются ли шаги записи: для дневной активности и тренировок,
+// которые задаются шагами, например бега и ходьбы.
+func (e Entry) StepBased() bool {
+	if e.Activity == DayActivity {
+		return true
+	}
+	a, ok := spentcalories.Lookup(e.Activity)
+	return ok && a.StepBased
+}
+
+// FromDayAction преобразует дневную активность в запись отчёта.
+func FromDayAction(a daysteps.DayAction) Entry {
+	return Entry{
+		Date:     a.Start,
+		Activity: DayActivity,
+		Steps:    a.Steps,
+		Duration: a.Duration,
+		Distance: a.Distance,
+		Calories: a.Calories,
+	}
+}
+
+// FromTraining преобразует тренировку в запись отчёта.
+func FromTraining(t spentcalories.Training) Entry {
+	return Entry{
+		Date:     t.Date,
+		Activity: t.Type,
+		Steps:    t.Steps,
+		Duration: t.Duration,
+		Distance: t.Distance,
+		Calories: t.Calories,
+	}
+}
+
+// Totals — суммарные показатели.
+type Totals struct {
+	Entries  int           // количество записей
+	Steps    int           // количество шагов
+	Duration time.Duration // время активности
+	Distance float64       // дистанция в км
+	Calories float64       // израсходованные калории
+}
+
+func (t *Totals) add(e Entry) {
+	t.Entries++
+	if e.StepBased() {
+		t.Steps += e.Steps
+	}
+	t.Duration += e.Duration
+	t.Distance += e.Distance
+	t.Calories += e.Calories
+}
+
+// div возвращает показатели, разделённые на n.
+func (t Totals) div(n int) Totals {
+	if n <= 0 {
+		return Totals{}
+	}
+	return Totals{
+		Entries:  t.Entries / n,
+		Steps:    t.Steps / n,
+		Duration: t.Duration / time.Duration(n),
+		Distance: t.Distance / float64(n),
+		Calories: t.Calories / float64(n),
+	}
+}
+
+// String возвращает описание показателей.
+func (t Totals) String() string {
+	return t.Text(i18n.Default, units.Metric)
+}
+
+// Text возвращает описание показателей на языке lang с дистанцией в системе единиц system.
+func (t Totals) Text(lang i18n.Lang, system units.System) string {
+	return lang.Sprintf(i18n.ReportTotals, t.Steps, system.Distance(t.Distance), system.DistanceUnit(lang), t.Calories, t.Duration)
+}
+
+// MarshalJSON кодирует показатели в JSON с временем активности в секундах.
+func (t Totals) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Entries      int     `json:"entries"`
+		Steps        int     `json:"steps"`
+		DurationS    float64 `json:"duration_s"`
+		DistanceKm   float64 `json:"distance_km"`
+		CaloriesKcal float64 `json:"calories_kcal"`
+	}{t.Entries, t.Steps, t.Duration.Seconds(), t.Distance, t.Calories})
+}
+
+// Summary — сводка за период.
+type Summary struct {
+	Period     Period
+	Start      time.Time         // начало периода
+	Total      Totals            // итоги за период
+	ByActivity map[string]Totals // итоги по видам активности
+	ActiveDays int               // количество дней с активностью
+	Average    Totals            // среднее за день с активностью
+	BestDay    time.Time         // день с наибольшим расходом калорий
+	Best       Totals            // итоги лучшего дня
+}
+
+// Build группирует записи по периодам и возвращает сводки в порядке возрастания дат.
+// Записи без даты пропускаются. Периоды и дни определяются по календарной дате записи.
+func Build(entries []Entry, period Period) []Summary {
+	summaries := make(map[string]*Summary)
+	days := make(map[string]map[string]*dayTotals)
+
+	for _, e := range entries {
+		if e.Date.IsZero() {
+			continue
+		}
+
+		start := period.Start(e.Date)
+		key := start.Format(time.DateOnly)
+
+		s, ok := summaries[key]
+		if !ok {
+			s = &Summary{Period: period, Start: start, ByActivity: make(map[string]Totals)}
+			summaries[key] = s
+			days[key] = make(map[string]*dayTotals)
+		}
+
+		s.Total.add(e)
+		byActivity := s.ByActivity[e.Activity]
+		byActivity.add(e)
+		s.ByActivity[e.Activity] = byActivity
+
+		day := Day.Start(e.Date)
+		dayKey := day.Format(time.DateOnly)
+		if days[key][dayKey] == nil {
+			days[key][dayKey] = &dayTotals{date: day}
+		}
+		days[key][dayKey].add(e)
+	}
+
+	result := make([]Summary, 0, len(summaries))
+	for _, key := range slices.Sorted(maps.Keys(summaries)) {
+		s := summaries[key]
+		s.ActiveDays = len(days[key])
+		s.Average = s.Total.div(s.ActiveDays)
+
+		for _, dayKey := range slices.Sorted(maps.Keys(days[key])) {
+			if d := days[key][dayKey]; s.BestDay.IsZero() || d.Calories > s.Best.Calories {
+				s.BestDay, s.Best = d.date, d.Totals
+			}
+		}
+
+		result = append(result, *s)
+	}
+	return result
+}
+
+// dayTotals — итоги одного дня внутри периода.
+type dayTotals struct {
+	Totals
+	date time.Time
+}
+
+// String возвращает текстовое описание сводки.
+func (s Summary) String() string {
+	return s.Text(i18n.Default, units.Metric)
+}
+
+// Text возвращает текстовое описание сводки на языке lang с дистанцией в системе единиц system.
+func (s Summary) Text(lang i18n.Lang, system units.System) string {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, s.Period.label(lang, s.Start))
+	b.WriteString(lang.Sprintf(i18n.ReportTotal, s.Total.Entries, s.Total.Text(lang, system)))
+	if s.Period != Day {
+		b.WriteString(lang.Sprintf(i18n.ReportAverage, s.ActiveDays, s.Average.Text(lang, system)))
+		b.WriteString(lang.Sprintf(i18n.ReportBestDay, s.BestDay.Format(time.DateOnly), s.Best.Calories))
+	}
+
+	b.WriteString(lang.Sprintf(i18n.ReportByActivity))
+	for _, activity := range slices.Sorted(maps.Keys(s.ByActivity)) {
+		t := s.ByActivity[activity]
+		b.WriteString(lang.Sprintf(i18n.ReportActivity, lang.Activity(activity), t.Entries, t.Text(lang, system)))
+	}
+
+	return b.String()
+}
+
+// MarshalJSON кодирует сводку в JSON.
+func (s Summary) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Period     Period            `json:"period"`
+		Start      string            `json:"start"`
+		Total      Totals            `json:"total"`
+		ByActivity map[string]Totals `json:"by_activity"`
+		ActiveDays int               `json:"active_days"`
+		Average    Totals            `json:"average"`
+		BestDay    string            `json:"best_day"`
+		Best       Totals            `json:"best"`
+	}{
+		Period:     s.Period,
+		Start:      s.Start.Format(time.DateOnly),
+		Total:      s.Total,
+		ByActivity: s.ByActivity,
+		ActiveDays: s.ActiveDays,
+		Average:    s.Average,
+		BestDay:    s.BestDay.Format(time.DateOnly),
+		Best:       s.Best,
+	})
+}
@@ -0,0 +1,191 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReportTestSuite struct {
+	suite.Suite
+}
+
+func TestReportSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
+
+func date(month time.Month, day, hour int) time.Time {
+	return time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+// testEntries — записи за среду и четверг одной недели и за понедельник следующей.
+func testEntries() []Entry {
+	return []Entry{
+		FromDayAction(daysteps.DayAction{Start: date(5, 1, 8), Steps: 6000, Duration: time.Hour, Distance: 3.9, Calories: 150}),
+		FromDayAction(daysteps.DayAction{Start: date(5, 1, 19), Steps: 4000, Duration: 30 * time.Minute, Distance: 2.6, Calories: 100}),
+		FromTraining(spentcalories.Training{Date: date(5, 2, 7), Type: "Бег", Duration: time.Hour, Distance: 10, Calories: 700}),
+		FromDayAction(daysteps.DayAction{Start: date(5, 6, 9), Steps: 8000, Duration: time.Hour, Distance: 5.2, Calories: 200}),
+		FromDayAction(daysteps.DayAction{Steps: 1000, Duration: time.Hour, Distance: 0.65, Calories: 30}),
+	}
+}
+
+func (suite *ReportTestSuite) TestPeriodStart() {
+	wednesday := time.Date(2024, 5, 1, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(suite.T(), date(5, 1, 0), Day.Start(wednesday))
+	assert.Equal(suite.T(), date(4, 29, 0), Week.Start(wednesday))
+	assert.Equal(suite.T(), date(5, 1, 0), Month.Start(wednesday))
+	assert.Equal(suite.T(), date(5, 6, 0), Week.Start(date(5, 12, 23)), "воскресенье относится к неделе с понедельника")
+}
+
+func (suite *ReportTestSuite) TestPeriodNext() {
+	assert.Equal(suite.T(), date(5, 2, 0), Day.Next(date(5, 1, 0)))
+	assert.Equal(suite.T(), date(5, 6, 0), Week.Next(date(4, 29, 0)))
+	assert.Equal(suite.T(), date(6, 1, 0), Month.Next(date(5, 1, 0)))
+}
+
+func (suite *ReportTestSuite) TestParsePeriod() {
+	p, err := ParsePeriod("Week")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Week, p)
+
+	_, err = ParsePeriod("year")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReportTestSuite) TestFromTraining() {
+	training, err := spentcalories.ComputeTraining("6000,Бег,0h40m", profile.Profile{Weight: 75.0, Height: 1.75})
+	require.NoError(suite.T(), err)
+	training.Date = date(5, 1, 19)
+
+	e := FromTraining(training)
+	assert.Equal(suite.T(), 6000, e.Steps, "шаги тренировки учитываются в итогах")
+
+	s := Build([]Entry{
+		FromDayAction(daysteps.DayAction{Start: date(5, 1, 8), Steps: 4000, Duration: time.Hour}),
+		e,
+	}, Day)
+	require.Len(suite.T(), s, 1)
+	assert.Equal(suite.T(), 10000, s[0].Total.Steps)
+	assert.Equal(suite.T(), 6000, s[0].ByActivity["Бег"].Steps)
+}
+
+func (suite *ReportTestSuite) TestFromTrainingNotStepBased() {
+	p := profile.Profile{Weight: 75.0, Height: 1.75}
+	for _, data := range []string{"5000,Велосипед,1h00m", "40,Плавание,1h00m,pool=50"} {
+		training, err := spentcalories.ComputeTraining(data, p)
+		require.NoError(suite.T(), err)
+		assert.Zero(suite.T(), FromTraining(training).Steps, "обороты колеса и бассейны не считаются шагами: %q", data)
+	}
+
+	s := Build([]Entry{
+		{Date: date(5, 1, 8), Activity: DayActivity, Steps: 4000},
+		{Date: date(5, 1, 19), Activity: "Велосипед", Steps: 5000},
+	}, Day)
+	require.Len(suite.T(), s, 1)
+	assert.Equal(suite.T(), 4000, s[0].Total.Steps, "шаги из старой истории для велосипеда не суммируются")
+}
+
+func (suite *ReportTestSuite) TestBuildDaily() {
+	summaries := Build(testEntries(), Day)
+	require.Len(suite.T(), summaries, 3, "записи без даты пропускаются")
+
+	first := summaries[0]
+	assert.Equal(suite.T(), date(5, 1, 0), first.Start)
+	assert.Equal(suite.T(), Totals{Entries: 2, Steps: 10000, Duration: 90 * time.Minute, Distance: 6.5, Calories: 250}, first.Total)
+	assert.Equal(suite.T(), 1, first.ActiveDays)
+}
+
+func (suite *ReportTestSuite) TestBuildWeekly() {
+	summaries := Build(testEntries(), Week)
+	require.Len(suite.T(), summaries, 2)
+
+	week := summaries[0]
+	assert.Equal(suite.T(), date(4, 29, 0), week.Start)
+	assert.Equal(suite.T(), 3, week.Total.Entries)
+	assert.Equal(suite.T(), 10000, week.Total.Steps)
+	assert.InDelta(suite.T(), 950.0, week.Total.Calories, 1e-9)
+	assert.Equal(suite.T(), 2, week.ActiveDays)
+
+	assert.Equal(suite.T(), 5000, week.Average.Steps)
+	assert.InDelta(suite.T(), 475.0, week.Average.Calories, 1e-9)
+	assert.Equal(suite.T(), 75*time.Minute, week.Average.Duration)
+
+	assert.Equal(suite.T(), date(5, 2, 0), week.BestDay)
+	assert.InDelta(suite.T(), 700.0, week.Best.Calories, 1e-9)
+
+	assert.Equal(suite.T(), 2, week.ByActivity[DayActivity].Entries)
+	assert.InDelta(suite.T(), 10.0, week.ByActivity["Бег"].Distance, 1e-9)
+}
+
+func (suite *ReportTestSuite) TestBuildMonthly() {
+	summaries := Build(testEntries(), Month)
+	require.Len(suite.T(), summaries, 1)
+	assert.Equal(suite.T(), 4, summaries[0].Total.Entries)
+	assert.Equal(suite.T(), 3, summaries[0].ActiveDays)
+}
+
+func (suite *ReportTestSuite) TestString() {
+	summaries := Build(testEntries(), Week)
+
+	want := "Неделя с 2024-04-29\n" +
+		"Всего: записей 3, шагов 10000, дистанция 16.50 км, 950.00 ккал, активность 2h30m0s.\n" +
+		"В среднем за активный день (2): шагов 5000, дистанция 8.25 км, 475.00 ккал, активность 1h15m0s.\n" +
+		"Лучший день: 2024-05-02, 700.00 ккал.\n" +
+		"По видам активности:\n" +
+		"  Бег: записей 1, шагов 0, дистанция 10.00 км, 700.00 ккал, активность 1h0m0s.\n" +
+		"  Дневная активность: записей 2, шагов 10000, дистанция 6.50 км, 250.00 ккал, активность 1h30m0s.\n"
+	assert.Equal(suite.T(), want, summaries[0].String())
+}
+
+func (suite *ReportTestSuite) TestJSON() {
+	summaries := Build(testEntries(), Month)
+
+	data, err := json.Marshal(summaries[0])
+	require.NoError(suite.T(), err)
+
+	var got map[string]any
+	require.NoError(suite.T(), json.Unmarshal(data, &got))
+	assert.Equal(suite.T(), "month", got["period"])
+	assert.Equal(suite.T(), "2024-05-01", got["start"])
+	assert.Equal(suite.T(), "2024-05-02", got["best_day"])
+	assert.Equal(suite.T(), 18000.0, got["total"].(map[string]any)["steps"])
+}
+
+func (suite *ReportTestSuite) TestEntryJSON() {
+	entry := FromDayAction(daysteps.DayAction{Start: date(5, 1, 8), Steps: 6000, Duration: 90 * time.Minute, Distance: 3.9, Calories: 150})
+
+	data, err := json.Marshal(entry)
+	require.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"date":"2024-05-01T08:00:00Z","activity":"Дневная активность","steps":6000,"duration_s":5400,"distance_km":3.9,"calories_kcal":150}`, string(data))
+
+	var got Entry
+	require.NoError(suite.T(), json.Unmarshal(data, &got))
+	assert.True(suite.T(), entry.Date.Equal(got.Date))
+	got.Date = entry.Date
+	assert.Equal(suite.T(), entry, got)
+}
+
+func (suite *ReportTestSuite) TestText() {
+	summaries := Build(testEntries(), Week)
+
+	assert.Equal(suite.T(), summaries[0].String(), summaries[0].Text(i18n.Russian, units.Metric))
+
+	want := "Week of 2024-04-29\n" +
+		"Total: 3 record(s), 10000 steps, distance 16.50 km, 950.00 kcal, active 2h30m0s.\n" +
+		"Average per active day (2): 5000 steps, distance 8.25 km, 475.00 kcal, active 1h15m0s.\n" +
+		"Best day: 2024-05-02, 700.00 kcal.\n" +
+		"By activity:\n" +
+		"  Run: 1 record(s), 0 steps, distance 10.00 km, 700.00 kcal, active 1h0m0s.\n" +
+		"  Daily activity: 2 record(s), 10000 steps, distance 6.50 km, 250.00 kcal, active 1h30m0s.\n"
+	assert.Equal(suite.T(), want, summaries[0].Text(i18n.English, units.Metric))
+}
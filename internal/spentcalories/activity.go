@@ -0,0 +1,231 @@
+package spentcalories
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/input"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+)
+
+// Workout содержит исходные данные тренировки, разобранные из входной строки.
+type Workout struct {
+	Activity string            // название вида тренировки
+	Steps    int               // количество шагов или других циклов движения, например оборотов колеса
+	Distance float64           // дистанция в км, если она указана вместо количества шагов
+	Duration time.Duration     // продолжительность
+	Params   map[string]string // дополнительные параметры вида ключ=значение
+}
+
+// Float возвращает числовой параметр тренировки или значение по умолчанию, если параметр не задан.
+func (w Workout) Float(key string, def float64) (float64, error) {
+	value, ok := w.Params[key]
+	if !ok {
+		return def, nil
+	}
+	return input.PositiveFloat(key, value)
+}
+
+// DistanceFunc рассчитывает дистанцию тренировки в км.
+type DistanceFunc func(w Workout, p profile.Profile) (float64, error)
+
+// CaloriesFunc рассчитывает количество израсходованных калорий.
+type CaloriesFunc func(w Workout, p profile.Profile) (float64, error)
+
+// CheckFunc проверяет параметры тренировки, которые нужны виду активности.
+type CheckFunc func(w Workout) error
+
+// Activity описывает вид тренировки.
+type Activity struct {
+	Name          string       // название, которое выводится в результатах
+	Aliases       []string     // дополнительные названия для разбора входных данных
+	Distance      DistanceFunc // модель расчёта дистанции
+	Calories      CaloriesFunc // формула расчёта калорий
+	DistanceInput bool         // можно ли во входной строке указать дистанцию вместо количества шагов
+	Params        []string     // допустимые дополнительные параметры
+	Check         CheckFunc    // проверка значений параметров, nil — значения не проверяются
+	PaceDistance  float64      // дистанция в км, на которую выводится темп; 0 — темп не выводится
+	Splits        bool         // оценивать ли время на стандартных дистанциях SplitDistances
+	StepBased     bool         // задаётся ли тренировка шагами; для других видов количество циклов, например оборотов колеса, не считается шагами
+}
+
+// check проверяет, что данные тренировки подходят для этого вида активности.
+func (a Activity) check(w Workout) error {
+	if w.Distance > 0 && !a.DistanceInput {
+		return &input.ParseError{
+			Field: input.FieldDistance,
+			Value: strconv.FormatFloat(w.Distance, 'f', -1, 64),
+			Err:   input.ErrInvalidDistance,
+			Cause: fmt.Errorf("для вида тренировки %q дистанция не указывается", a.Name),
+		}
+	}
+	// Параметры проверяются по порядку, чтобы при нескольких ошибках всегда сообщать о первой.
+	for _, key := range slices.Sorted(maps.Keys(w.Params)) {
+		value := w.Params[key]
+		if !slices.Contains(a.Params, key) && !slices.Contains(commonParams, key) {
+			return &input.ParseError{
+				Field: key,
+				Value: value,
+				Err:   input.ErrInvalidParam,
+				Cause: fmt.Errorf("параметр не поддерживается для вида тренировки %q", a.Name),
+			}
+		}
+	}
+	if a.Check != nil {
+		return a.Check(w)
+	}
+	return nil
+}
+
+// commonParams — параметры, допустимые для любого вида тренировки.
+var commonParams = []string{HeartRateParam}
+
+// spentCalories рассчитывает калории. Если в параметрах тренировки указан средний пульс,
+// калории считаются по пульсу, а не по формуле вида тренировки.
+func (a Activity) spentCalories(w Workout, p profile.Profile) (float64, error) {
+	hr, ok, err := w.heartRate()
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		return HeartRateCalories(hr, w.Duration, p)
+	}
+	return a.Calories(w, p)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Activity{}
+	names      []string
+)
+
+func init() {
+	mustRegister(Activity{
+		Name:         Running,
+		Distance:     stepDistance,
+		Calories:     stepCalories,
+		PaceDistance: 1,
+		Splits:       true,
+		StepBased:    true,
+	})
+	mustRegister(Activity{
+		Name:      Walking,
+		Distance:  stepDistance,
+		Calories:  stepCalories,
+		StepBased: true,
+	})
+}
+
+// Register добавляет вид тренировки в реестр.
+// Название и псевдонимы не должны совпадать с уже зарегистрированными.
+func Register(a Activity) error {
+	if a.Name == "" {
+		return errors.New("не задано название вида тренировки")
+	}
+	if a.Distance == nil || a.Calories == nil {
+		return fmt.Errorf("для вида тренировки %q не заданы формулы расчёта", a.Name)
+	}
+
+	keys := append([]string{a.Name}, a.Aliases...)
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, key := range keys {
+		if _, ok := registry[normalize(key)]; ok {
+			return fmt.Errorf("вид тренировки %q уже зарегистрирован", key)
+		}
+	}
+	for _, key := range keys {
+		registry[normalize(key)] = a
+	}
+	names = append(names, a.Name)
+	sort.Strings(names)
+
+	return nil
+}
+
+// Lookup ищет вид тренировки по названию или псевдониму без учёта регистра.
+// Также принимаются названия из каталогов пакета i18n, например "Run" для бега.
+func Lookup(name string) (Activity, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	if a, ok := registry[normalize(name)]; ok {
+		return a, true
+	}
+	if canonical, ok := i18n.Canonical(name); ok {
+		a, ok := registry[normalize(canonical)]
+		return a, ok
+	}
+	return Activity{}, false
+}
+
+// Activities возвращает отсортированный список названий зарегистрированных видов тренировок.
+func Activities() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return append([]string(nil), names...)
+}
+
+// Params возвращает отсортированный список дополнительных параметров, допустимых
+// хотя бы для одного зарегистрированного вида тренировки.
+func Params() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	params := slices.Clone(commonParams)
+	for _, a := range registry {
+		params = append(params, a.Params...)
+	}
+	slices.Sort(params)
+	return slices.Compact(params)
+}
+
+func mustRegister(a Activity) {
+	if err := Register(a); err != nil {
+		panic(err)
+	}
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// stepDistance рассчитывает дистанцию по количеству шагов с моделью длины шага из профиля.
+// Если модель не выбрана, длина шага берётся из калибровки, а без неё — рассчитывается по росту.
+// Измеренная дистанция, если она есть, используется без изменений.
+func stepDistance(w Workout, p profile.Profile) (float64, error) {
+	if w.Distance > 0 {
+		return w.Distance, nil
+	}
+	model := stride.Resolve(p, stride.Default)
+	return stride.Distance(model, p, w.Activity, w.Steps), nil
+}
+
+// stepCalories рассчитывает калории при беге и ходьбе по дистанции из stepDistance
+// с моделью расчёта калорий из профиля.
+func stepCalories(w Workout, p profile.Profile) (float64, error) {
+	if w.Distance <= 0 && w.Steps <= 0 {
+		return 0, errors.New("количество шагов должно быть больше нуля")
+	}
+	if err := validateMeasures(p.Weight, p.Height, w.Duration); err != nil {
+		return 0, err
+	}
+
+	dist, err := stepDistance(w, p)
+	if err != nil {
+		return 0, err
+	}
+	return calorieModel(p).Calories(p, w.Activity, dist, w.Duration)
+}
@@ -0,0 +1,119 @@
+package spentcalories
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+)
+
+// CalorieModel — модель расчёта калорий для бега и ходьбы. Выбранная модель хранится в профиле пользователя.
+type CalorieModel = profile.CalorieModel
+
+// Formula — исходная формула: калории пропорциональны весу, средней скорости и продолжительности,
+// а для ходьбы дополнительно умножаются на коэффициент walkingCaloriesCoefficient.
+type Formula struct{}
+
+// Calories рассчитывает калории по исходной формуле.
+func (Formula) Calories(p profile.Profile, activity string, distance float64, duration time.Duration) (float64, error) {
+	calories := caloriesBySpeed(p.Weight, speed(distance, duration), duration)
+	if normalize(activity) == normalize(Walking) {
+		calories *= walkingCaloriesCoefficient
+	}
+	return calories, nil
+}
+
+// METTable — модель, в которой калории равны MET × вес × часы,
+// а MET зависит от вида активности и средней скорости (Compendium of Physical Activities).
+type METTable struct{}
+
+// metTable — значения MET в зависимости от скорости в км/ч.
+// Значение применяется, если скорость не меньше указанной; строки упорядочены по убыванию скорости.
+type metTable []struct {
+	speed float64 // км/ч
+	met   float64
+}
+
+// met возвращает MET для скорости speed или slow, если скорость ниже значений из таблицы.
+func (t metTable) met(speed, slow float64) float64 {
+	for _, v := range t {
+		if speed >= v.speed {
+			return v.met
+		}
+	}
+	return slow
+}
+
+// Значения MET для ходьбы и бега.
+var (
+	walkingMETs = metTable{
+		{speed: 7.2, met: 7.0},
+		{speed: 6.4, met: 5.0},
+		{speed: 5.6, met: 4.3},
+		{speed: 4.8, met: 3.5},
+		{speed: 4.0, met: 3.0},
+		{speed: 3.2, met: 2.8},
+	}
+	runningMETs = metTable{
+		{speed: 19.3, met: 19.0},
+		{speed: 17.7, met: 16.0},
+		{speed: 16.1, met: 14.5},
+		{speed: 14.5, met: 12.8},
+		{speed: 12.9, met: 11.8},
+		{speed: 11.3, met: 11.0},
+		{speed: 10.8, met: 10.5},
+		{speed: 9.7, met: 9.8},
+		{speed: 8.0, met: 8.3},
+	}
+)
+
+// Константы модели METTable.
+const (
+	walkingSlowMET = 2.0 // MET при ходьбе медленнее 3.2 км/ч.
+	runningSlowMET = 6.0 // MET при беге медленнее 8 км/ч.
+)
+
+// Calories рассчитывает калории по таблице MET.
+func (METTable) Calories(p profile.Profile, activity string, distance float64, duration time.Duration) (float64, error) {
+	if p.Weight <= 0 {
+		return 0, errors.New("вес должен быть больше нуля")
+	}
+	if duration <= 0 {
+		return 0, errors.New("продолжительность должна быть больше нуля")
+	}
+
+	v := speed(distance, duration)
+
+	var met float64
+	switch normalize(activity) {
+	case normalize(Walking):
+		met = walkingMETs.met(v, walkingSlowMET)
+	case normalize(Running):
+		met = runningMETs.met(v, runningSlowMET)
+	default:
+		return 0, fmt.Errorf("в таблице MET нет вида тренировки %q", activity)
+	}
+
+	return met * p.Weight * duration.Hours(), nil
+}
+
+// ParseCalorieModel возвращает модель расчёта калорий по названию: formula или met.
+func ParseCalorieModel(name string) (CalorieModel, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "formula":
+		return Formula{}, nil
+	case "met":
+		return METTable{}, nil
+	}
+	return nil, fmt.Errorf("неизвестная модель расчёта калорий: %q", name)
+}
+
+// calorieModel возвращает модель из профиля, а если она не выбрана — исходную формулу.
+func calorieModel(p profile.Profile) CalorieModel {
+	if p.CalorieModel != nil {
+		return p.CalorieModel
+	}
+	return Formula{}
+}
@@ -0,0 +1,69 @@
+package spentcalories
+
+import (
+	"errors"
+
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Cycling — название езды на велосипеде.
+const Cycling = "Велосипед"
+
+// Константы для расчёта езды на велосипеде.
+const (
+	wheelParam         = "wheel" // параметр с длиной окружности колеса в метрах.
+	defaultWheelLength = 2.096   // длина окружности колеса 700x23C в метрах.
+	cyclingSlowMET     = 4.0     // MET при скорости ниже значений из таблицы.
+)
+
+// cyclingMETs — значения MET в зависимости от скорости (Compendium of Physical Activities).
+var cyclingMETs = metTable{
+	{speed: 30, met: 15.8},
+	{speed: 25, met: 12.0},
+	{speed: 22, met: 10.0},
+	{speed: 19, met: 8.0},
+	{speed: 16, met: 6.8},
+}
+
+func init() {
+	mustRegister(Activity{
+		Name:          Cycling,
+		Aliases:       []string{"Вело"},
+		Distance:      cyclingDistance,
+		Calories:      cyclingSpentCalories,
+		DistanceInput: true,
+		Params:        []string{wheelParam},
+	})
+}
+
+// cyclingDistance рассчитывает дистанцию по количеству оборотов колеса.
+// Если дистанция указана во входных данных, она используется без изменений.
+func cyclingDistance(w Workout, p profile.Profile) (float64, error) {
+	if w.Distance > 0 {
+		return w.Distance, nil
+	}
+
+	wheel, err := w.Float(wheelParam, defaultWheelLength)
+	if err != nil {
+		return 0, err
+	}
+	return units.KmFromMeters(float64(w.Steps) * wheel), nil
+}
+
+// cyclingSpentCalories рассчитывает калории по MET, зависящему от средней скорости.
+func cyclingSpentCalories(w Workout, p profile.Profile) (float64, error) {
+	if p.Weight <= 0 {
+		return 0, errors.New("вес должен быть больше нуля")
+	}
+	if w.Duration <= 0 {
+		return 0, errors.New("продолжительность должна быть больше нуля")
+	}
+
+	dist, err := cyclingDistance(w, p)
+	if err != nil {
+		return 0, err
+	}
+
+	return cyclingMETs.met(speed(dist, w.Duration), cyclingSlowMET) * p.Weight * w.Duration.Hours(), nil
+}
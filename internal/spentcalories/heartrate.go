@@ -0,0 +1,66 @@
+package spentcalories
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/input"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+)
+
+// HeartRateParam — параметр тренировки со средним пульсом в уд/мин. Допустим для любого вида тренировки.
+const HeartRateParam = "hr"
+
+// Константы для расчёта калорий по пульсу.
+const (
+	minHeartRate = 40    // наименьший допустимый средний пульс в уд/мин.
+	maxHeartRate = 220   // наибольший допустимый средний пульс в уд/мин.
+	kJInKcal     = 4.184 // количество кДж в ккал.
+)
+
+// keytelCoefficients — коэффициенты формулы Keytel et al. (2005) для расчёта расхода энергии
+// в кДж/мин по пульсу, весу и возрасту.
+var keytelCoefficients = map[profile.Sex]struct {
+	intercept, heartRate, weight, age float64
+}{
+	profile.Male:   {intercept: -55.0969, heartRate: 0.6309, weight: 0.1988, age: 0.2017},
+	profile.Female: {intercept: -20.4022, heartRate: 0.4472, weight: -0.1263, age: 0.074},
+}
+
+// HeartRateCalories рассчитывает калории по среднему пульсу heartRate в уд/мин по формуле Keytel et al. (2005).
+// Для расчёта в профиле должны быть указаны вес, возраст и пол.
+func HeartRateCalories(heartRate float64, duration time.Duration, p profile.Profile) (float64, error) {
+	c, ok := keytelCoefficients[p.Sex]
+	switch {
+	case p.Weight <= 0:
+		return 0, errors.New("вес должен быть больше нуля")
+	case p.Age <= 0:
+		return 0, errors.New("для расчёта калорий по пульсу нужно указать возраст")
+	case !ok:
+		return 0, errors.New("для расчёта калорий по пульсу нужно указать пол")
+	case duration <= 0:
+		return 0, errors.New("продолжительность должна быть больше нуля")
+	case heartRate < minHeartRate || heartRate > maxHeartRate:
+		return 0, &input.ParseError{
+			Field: HeartRateParam,
+			Value: strconv.FormatFloat(heartRate, 'f', -1, 64),
+			Err:   input.ErrInvalidParam,
+			Cause: fmt.Errorf("пульс должен быть от %d до %d уд/мин", minHeartRate, maxHeartRate),
+		}
+	}
+
+	perMinute := (c.intercept + c.heartRate*heartRate + c.weight*p.Weight + c.age*float64(p.Age)) / kJInKcal
+	return math.Max(perMinute, 0) * duration.Minutes(), nil
+}
+
+// heartRate возвращает средний пульс из параметров тренировки и сообщает, указан ли он.
+func (w Workout) heartRate() (float64, bool, error) {
+	if _, ok := w.Params[HeartRateParam]; !ok {
+		return 0, false, nil
+	}
+	hr, err := w.Float(HeartRateParam, 0)
+	return hr, true, err
+}
@@ -1,7 +1,17 @@
 package spentcalories
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/input"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+	"github.com/Yandex-Practicum/tracker/internal/units"
 )
 
 // Основные константы, необходимые для расчетов.
@@ -9,30 +19,318 @@ const (
 	lenStep                    = 0.65 // средняя длина шага.
 	mInKm                      = 1000 // количество метров в километре.
 	minInH                     = 60   // количество минут в часе.
-	stepLengthCoefficient      = 0.45 // коэффициент для расчета длины шага на основе роста.
 	walkingCaloriesCoefficient = 0.5  // коэффициент для расчета калорий при ходьбе
+	distanceSuffix             = "km" // суффикс дистанции во входных данных.
+)
+
+// Виды тренировок.
+const (
+	Running = "Бег"
+	Walking = "Ходьба"
 )
 
+// Training содержит результаты расчёта одной тренировки.
+type Training struct {
+	Date         time.Time     // дата тренировки, нулевое значение — не указана
+	Type         string        // вид тренировки
+	Steps        int           // количество шагов; 0 — указана дистанция или вид тренировки не задаётся шагами
+	Duration     time.Duration // продолжительность
+	Distance     float64       // дистанция в км
+	Speed        float64       // средняя скорость в км/ч
+	Calories     float64       // израсходованные калории
+	Pace         time.Duration // время прохождения PaceDistance
+	PaceDistance float64       // дистанция в км, для которой рассчитан темп; 0 — темп не рассчитывается
+	Splits       []Split       // оценка времени на стандартных дистанциях, если она нужна для вида тренировки
+}
+
+// String возвращает описание тренировки в формате, который выводит TrainingInfo.
+func (t Training) String() string {
+	return t.Text(i18n.Default, units.Metric)
+}
+
+// Text возвращает описание тренировки на языке lang с дистанцией и скоростью в системе единиц system.
+// Темп бега в описание не входит, чтобы вывод TrainingInfo для бега остался прежним;
+// его выводят макеты пакета layout.
+func (t Training) Text(lang i18n.Lang, system units.System) string {
+	s := lang.Sprintf(i18n.Training, lang.Activity(t.Type), t.Duration.Hours(),
+		system.Distance(t.Distance), system.DistanceUnit(lang), system.Speed(t.Speed), system.SpeedUnit(lang), t.Calories)
+	if t.PaceDistance > 0 && t.Type != Running {
+		s += lang.Sprintf(i18n.TrainingPace, FormatPace(t.Pace), PaceUnit(lang, t.PaceDistance))
+	}
+	return s
+}
+
+// trainingJSON — представление тренировки в JSON. Единицы измерения указаны в названиях полей.
+type trainingJSON struct {
+	Date           time.Time `json:"date,omitzero"`
+	Type           string    `json:"type"`
+	Steps          int       `json:"steps,omitempty"`
+	DurationS      float64   `json:"duration_s"`
+	DistanceKm     float64   `json:"distance_km"`
+	SpeedKmh       float64   `json:"speed_kmh"`
+	CaloriesKcal   float64   `json:"calories_kcal"`
+	PaceS          float64   `json:"pace_s,omitempty"`
+	PaceDistanceKm float64   `json:"pace_distance_km,omitempty"`
+	Splits         []Split   `json:"splits,omitempty"`
+}
+
+// MarshalJSON кодирует тренировку в JSON с продолжительностью и темпом в секундах.
+func (t Training) MarshalJSON() ([]byte, error) {
+	return json.Marshal(trainingJSON{
+		Date:           t.Date,
+		Type:           t.Type,
+		Steps:          t.Steps,
+		DurationS:      t.Duration.Seconds(),
+		DistanceKm:     t.Distance,
+		SpeedKmh:       t.Speed,
+		CaloriesKcal:   t.Calories,
+		PaceS:          t.Pace.Seconds(),
+		PaceDistanceKm: t.PaceDistance,
+		Splits:         t.Splits,
+	})
+}
+
+// UnmarshalJSON декодирует тренировку, закодированную MarshalJSON.
+func (t *Training) UnmarshalJSON(data []byte) error {
+	var v trainingJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*t = Training{
+		Date:         v.Date,
+		Type:         v.Type,
+		Steps:        v.Steps,
+		Duration:     time.Duration(v.DurationS * float64(time.Second)),
+		Distance:     v.DistanceKm,
+		Speed:        v.SpeedKmh,
+		Calories:     v.CaloriesKcal,
+		Pace:         time.Duration(v.PaceS * float64(time.Second)),
+		PaceDistance: v.PaceDistanceKm,
+		Splits:       v.Splits,
+	}
+	return nil
+}
+
 func parseTraining(data string) (int, string, time.Duration, error) {
-	// TODO: реализовать функцию
+	name, w, err := parseWorkout(data)
+	if err != nil {
+		return 0, "", 0, err
+	}
+	return w.Steps, name, w.Duration, nil
+}
+
+// parseWorkout разбирает строку вида "шаги,вид,продолжительность[,ключ=значение...]".
+// Вместо количества шагов можно указать дистанцию с суффиксом km, например "25.5km".
+func parseWorkout(data string) (string, Workout, error) {
+	parts, err := input.Fields(data, 3, -1)
+	if err != nil {
+		return "", Workout{}, err
+	}
+
+	var w Workout
+
+	if value, ok := strings.CutSuffix(parts[0], distanceSuffix); ok {
+		if w.Distance, err = input.Distance(value, 1); err != nil {
+			return "", Workout{}, err
+		}
+	} else {
+		if w.Steps, err = input.Steps(parts[0], 1); err != nil {
+			return "", Workout{}, err
+		}
+	}
+
+	if w.Duration, err = input.Duration(parts[2], 3); err != nil {
+		return "", Workout{}, err
+	}
+
+	for i, param := range parts[3:] {
+		key, value, err := input.Param(param, i+4)
+		if err != nil {
+			return "", Workout{}, err
+		}
+		if w.Params == nil {
+			w.Params = make(map[string]string)
+		}
+		w.Params[key] = value
+	}
+
+	return parts[1], w, nil
 }
 
+// distance возвращает дистанцию в км по длине шага из роста, как модель stride.DefaultHeightBased.
 func distance(steps int, height float64) float64 {
-	// TODO: реализовать функцию
+	return stride.Distance(stride.DefaultHeightBased, profile.Profile{Height: height}, "", steps)
 }
 
 func meanSpeed(steps int, height float64, duration time.Duration) float64 {
-	// TODO: реализовать функцию
+	return speed(distance(steps, height), duration)
+}
+
+// speed возвращает среднюю скорость в км/ч для дистанции в км.
+func speed(distance float64, duration time.Duration) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return distance / duration.Hours()
+}
+
+// pace возвращает время прохождения отрезка paceDistance км при равномерном движении.
+func pace(distance, paceDistance float64, duration time.Duration) time.Duration {
+	if distance <= 0 {
+		return 0
+	}
+	return time.Duration(float64(duration) * paceDistance / distance).Round(time.Second)
 }
 
+// FormatPace форматирует темп в виде минуты:секунды.
+func FormatPace(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// PaceUnit возвращает подпись отрезка km, на который рассчитан темп, на языке lang, например "км" или "100 м".
+func PaceUnit(lang i18n.Lang, km float64) string {
+	if km == 1 {
+		return lang.Sprintf(i18n.PaceKm)
+	}
+	return lang.Sprintf(i18n.PaceMeters, units.MetersFromKm(km))
+}
+
+// ComputeTraining разбирает строку тренировки и рассчитывает её показатели для профиля p.
+// Вид тренировки ищется в реестре зарегистрированных активностей.
+func ComputeTraining(data string, p profile.Profile) (Training, error) {
+	if err := p.Validate(); err != nil {
+		return Training{}, err
+	}
+
+	name, w, err := parseWorkout(data)
+	if err != nil {
+		return Training{}, err
+	}
+
+	activity, ok := Lookup(name)
+	if !ok {
+		return Training{}, &input.ParseError{Field: input.FieldActivity, Value: name, Pos: 2, Err: input.ErrUnknownActivity}
+	}
+	if err := activity.check(w); err != nil {
+		return Training{}, err
+	}
+
+	return compute(activity, w, p)
+}
+
+// ComputeWorkout рассчитывает показатели тренировки вида name по уже известным данным,
+// например по дистанции и продолжительности из файла трека. Калории считаются
+// по тем же формулам, что и для тренировок, заданных строкой.
+func ComputeWorkout(name string, w Workout, p profile.Profile) (Training, error) {
+	if err := p.Validate(); err != nil {
+		return Training{}, err
+	}
+
+	activity, ok := Lookup(name)
+	if !ok {
+		return Training{}, &input.ParseError{Field: input.FieldActivity, Value: name, Err: input.ErrUnknownActivity}
+	}
+
+	return compute(activity, w, p)
+}
+
+// compute рассчитывает показатели тренировки. Измеренная дистанция w.Distance важнее расчётной.
+func compute(activity Activity, w Workout, p profile.Profile) (Training, error) {
+	w.Activity = activity.Name
+
+	calories, err := activity.spentCalories(w, p)
+	if err != nil {
+		return Training{}, err
+	}
+
+	dist := w.Distance
+	if dist <= 0 {
+		if dist, err = activity.Distance(w, p); err != nil {
+			return Training{}, err
+		}
+	}
+
+	training := Training{
+		Type:     activity.Name,
+		Duration: w.Duration,
+		Distance: dist,
+		Speed:    speed(dist, w.Duration),
+		Calories: calories,
+	}
+	if activity.StepBased {
+		training.Steps = w.Steps
+	}
+	if activity.PaceDistance > 0 {
+		training.Pace = pace(dist, activity.PaceDistance, w.Duration)
+		training.PaceDistance = activity.PaceDistance
+	}
+	if activity.Splits {
+		training.Splits = EstimateSplits(dist, w.Duration)
+	}
+
+	return training, nil
+}
+
+// TrainingInfo возвращает текстовое описание тренировки.
 func TrainingInfo(data string, weight, height float64) (string, error) {
-	// TODO: реализовать функцию
+	training, err := ComputeTraining(data, profile.Profile{Weight: weight, Height: height})
+	if err != nil {
+		return "", err
+	}
+	return training.String(), nil
 }
 
 func RunningSpentCalories(steps int, weight, height float64, duration time.Duration) (float64, error) {
-	// TODO: реализовать функцию
+	if err := validate(steps, weight, height, duration); err != nil {
+		return 0, err
+	}
+
+	return caloriesBySpeed(weight, meanSpeed(steps, height, duration), duration), nil
 }
 
 func WalkingSpentCalories(steps int, weight, height float64, duration time.Duration) (float64, error) {
-	// TODO: реализовать функцию
+	calories, err := RunningSpentCalories(steps, weight, height, duration)
+	if err != nil {
+		return 0, err
+	}
+	return calories * walkingCaloriesCoefficient, nil
+}
+
+// SpentCalories рассчитывает калории для вида тренировки из реестра.
+// Если в параметрах тренировки указан средний пульс, калории считаются по пульсу.
+func SpentCalories(name string, w Workout, p profile.Profile) (float64, error) {
+	activity, ok := Lookup(name)
+	if !ok {
+		return 0, &input.ParseError{Field: input.FieldActivity, Value: name, Err: input.ErrUnknownActivity}
+	}
+	w.Activity = activity.Name
+	return activity.spentCalories(w, p)
+}
+
+// caloriesBySpeed рассчитывает калории при беге со средней скоростью speed км/ч.
+func caloriesBySpeed(weight, speed float64, duration time.Duration) float64 {
+	return weight * speed * duration.Minutes() / minInH
+}
+
+// validate проверяет входные данные для расчёта калорий.
+func validate(steps int, weight, height float64, duration time.Duration) error {
+	if steps <= 0 {
+		return errors.New("количество шагов должно быть больше нуля")
+	}
+	return validateMeasures(weight, height, duration)
+}
+
+// validateMeasures проверяет вес, рост и продолжительность.
+func validateMeasures(weight, height float64, duration time.Duration) error {
+	switch {
+	case weight <= 0:
+		return errors.New("вес должен быть больше нуля")
+	case height <= 0:
+		return errors.New("рост должен быть больше нуля")
+	case duration <= 0:
+		return errors.New("продолжительность должна быть больше нуля")
+	}
+	return nil
 }
@@ -1,9 +1,14 @@
 package spentcalories
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/input"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/units"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -642,7 +647,7 @@ func (suite *SpentCaloriesTestSuite) TestTrainingInfo() {
 		},
 		{
 			name:    "неизвестный тип тренировки",
-			input:   "6000,Плавание,1h00m",
+			input:   "6000,Теннис,1h00m",
 			weight:  75.0,
 			height:  1.75,
 			want:    "",
@@ -650,7 +655,7 @@ func (suite *SpentCaloriesTestSuite) TestTrainingInfo() {
 		},
 		{
 			name:    "неизвестный тип тренировки - проверка текста ошибки",
-			input:   "6000,Плавание,1h00m",
+			input:   "6000,Теннис,1h00m",
 			weight:  75.0,
 			height:  1.75,
 			want:    "",
@@ -700,3 +705,468 @@ func (suite *SpentCaloriesTestSuite) TestTrainingInfo() {
 		})
 	}
 }
+
+func (suite *SpentCaloriesTestSuite) TestComputeTraining() {
+	got, err := ComputeTraining("6000,Бег,1h00m", profile.Profile{Weight: 75.0, Height: 1.75})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Бег", got.Type)
+	assert.Equal(suite.T(), time.Hour, got.Duration)
+	assert.InDelta(suite.T(), 4.725, got.Distance, 1e-9)
+	assert.InDelta(suite.T(), 4.725, got.Speed, 1e-9)
+	assert.InDelta(suite.T(), 354.375, got.Calories, 1e-9)
+	assert.Equal(suite.T(), "Тип тренировки: Бег\nДлительность: 1.00 ч.\nДистанция: 4.72 км.\nСкорость: 4.72 км/ч\nСожгли калорий: 354.38\n", got.String())
+
+	_, err = ComputeTraining("6000,Теннис,1h00m", profile.Profile{Weight: 75.0, Height: 1.75})
+	assert.Error(suite.T(), err)
+
+	_, err = ComputeTraining("6000,Бег,1h00m", profile.Profile{Weight: 75.0})
+	assert.Error(suite.T(), err, "ожидалась ошибка для профиля без роста")
+
+	got, err = ComputeTraining("6000,Бег,1h00m", profile.Profile{Weight: 75.0, Height: 1.75, StrideLength: 0.8})
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 4.8, got.Distance, 1e-9)
+	assert.InDelta(suite.T(), 360.0, got.Calories, 1e-9)
+}
+
+func (suite *SpentCaloriesTestSuite) TestRegister() {
+	// реестр общий для пакета, поэтому при повторном запуске тестов регистрация пропускается
+	if _, ok := Lookup("Гребля"); !ok {
+		err := Register(Activity{
+			Name:    "Гребля",
+			Aliases: []string{"Rowing"},
+			Distance: func(w Workout, p profile.Profile) (float64, error) {
+				return float64(w.Steps) * 10 / mInKm, nil
+			},
+			Calories: func(w Workout, p profile.Profile) (float64, error) {
+				return 7 * p.Weight * w.Duration.Hours(), nil
+			},
+		})
+		assert.NoError(suite.T(), err)
+	}
+	assert.Contains(suite.T(), Activities(), "Гребля")
+	assert.Equal(suite.T(), []string{HeartRateParam, poolParam, strokeParam, wheelParam}, Params())
+
+	got, err := ComputeTraining("500,rowing,30m", profile.Profile{Weight: 80.0, Height: 1.80})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Гребля", got.Type)
+	assert.InDelta(suite.T(), 5.0, got.Distance, 1e-9)
+	assert.InDelta(suite.T(), 10.0, got.Speed, 1e-9)
+	assert.InDelta(suite.T(), 280.0, got.Calories, 1e-9)
+
+	err = Register(Activity{Name: "бег", Distance: stepDistance, Calories: stepCalories})
+	assert.Error(suite.T(), err, "повторная регистрация должна завершаться ошибкой")
+
+	err = Register(Activity{Name: "Без формул"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestCycling() {
+	tests := []struct {
+		name     string
+		input    string
+		wantDist float64
+		wantCal  float64
+		wantErr  bool
+	}{
+		{
+			name:     "обороты колеса по умолчанию",
+			input:    "10000,Велосипед,1h00m",
+			wantDist: 20.96,
+			wantCal:  8.0 * 75.0,
+		},
+		{
+			name:     "обороты колеса с заданной окружностью",
+			input:    "10000,Велосипед,1h00m,wheel=2.5",
+			wantDist: 25,
+			wantCal:  12.0 * 75.0,
+		},
+		{
+			name:     "дистанция вместо оборотов",
+			input:    "12km,Вело,1h00m",
+			wantDist: 12,
+			wantCal:  4.0 * 75.0,
+		},
+		{
+			name:    "неверная окружность колеса",
+			input:   "10000,Велосипед,1h00m,wheel=abc",
+			wantErr: true,
+		},
+		{
+			name:    "неизвестный параметр",
+			input:   "10000,Велосипед,1h00m,pool=25",
+			wantErr: true,
+		},
+		{
+			name:    "дистанция для ходьбы не поддерживается",
+			input:   "5km,Ходьба,1h00m",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := ComputeTraining(tt.input, profile.Profile{Weight: 75.0, Height: 1.75})
+
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+				return
+			}
+
+			assert.NoError(suite.T(), err)
+			assert.Equal(suite.T(), "Велосипед", got.Type)
+			assert.InDelta(suite.T(), tt.wantDist, got.Distance, 1e-9)
+			assert.InDelta(suite.T(), tt.wantDist, got.Speed, 1e-9)
+			assert.InDelta(suite.T(), tt.wantCal, got.Calories, 1e-9)
+		})
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestSwimming() {
+	tests := []struct {
+		name     string
+		input    string
+		wantDist float64
+		wantCal  float64
+		wantPace time.Duration
+		wantErr  bool
+	}{
+		{
+			name:     "бассейн по умолчанию",
+			input:    "40,Плавание,30m",
+			wantDist: 1,
+			wantCal:  5.8 * 75.0 * 0.5,
+			wantPace: 3 * time.Minute,
+		},
+		{
+			name:     "длинный бассейн и брасс",
+			input:    "20,Плавание,40m,pool=50,stroke=брасс",
+			wantDist: 1,
+			wantCal:  5.3 * 75.0 * 40 / 60,
+			wantPace: 4 * time.Minute,
+		},
+		{
+			name:    "неизвестный стиль",
+			input:   "20,Плавание,40m,stroke=кроль на боку",
+			wantErr: true,
+		},
+		{
+			name:    "неверная длина бассейна",
+			input:   "20,Плавание,40m,pool=0",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := ComputeTraining(tt.input, profile.Profile{Weight: 75.0, Height: 1.75})
+
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+				return
+			}
+
+			assert.NoError(suite.T(), err)
+			assert.Equal(suite.T(), "Плавание", got.Type)
+			assert.InDelta(suite.T(), tt.wantDist, got.Distance, 1e-9)
+			assert.InDelta(suite.T(), tt.wantCal, got.Calories, 1e-9)
+			assert.Equal(suite.T(), tt.wantPace, got.Pace)
+		})
+	}
+
+	p := profile.Profile{Weight: 75.0, Height: 1.75, Age: 30, Sex: profile.Male}
+	_, err := ComputeTraining("40,Плавание,1h,hr=150,stroke=Garbage", p)
+	var parseErr *input.ParseError
+	if assert.ErrorAs(suite.T(), err, &parseErr, "стиль проверяется и при расчёте по пульсу") {
+		assert.ErrorIs(suite.T(), err, input.ErrInvalidParam)
+		assert.Equal(suite.T(), "stroke", parseErr.Field)
+		assert.Equal(suite.T(), "Garbage", parseErr.Value, "в ошибке указано исходное значение")
+	}
+
+	got, err := TrainingInfo("40,Плавание,30m", 75.0, 1.75)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Тип тренировки: Плавание\nДлительность: 0.50 ч.\nДистанция: 1.00 км.\nСкорость: 2.00 км/ч\nСожгли калорий: 217.50\nТемп: 3:00 /100 м\n", got)
+}
+
+func (suite *SpentCaloriesTestSuite) TestCalibratedStride() {
+	p := profile.Profile{Weight: 75.0, Height: 1.75}
+	assert.NoError(suite.T(), p.Calibrate("Бег", 1000, 1000))
+
+	run, err := ComputeTraining("6000,Бег,1h00m", p)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 6.0, run.Distance, 1e-9)
+	assert.InDelta(suite.T(), 450.0, run.Calories, 1e-9)
+
+	walk, err := ComputeTraining("6000,Ходьба,1h00m", p)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 4.725, walk.Distance, 1e-9, "для ходьбы калибровки нет, длина шага по росту")
+}
+
+func (suite *SpentCaloriesTestSuite) TestParseErrors() {
+	tests := []struct {
+		input   string
+		wantErr error
+	}{
+		{input: "678,Ходьба", wantErr: input.ErrInvalidFormat},
+		{input: "abc,Ходьба,1h30m", wantErr: input.ErrInvalidSteps},
+		{input: "678,Ходьба,30", wantErr: input.ErrInvalidDuration},
+		{input: "0.0km,Велосипед,1h", wantErr: input.ErrInvalidDistance},
+		{input: "678,Ходьба,1h30m,extra", wantErr: input.ErrInvalidParam},
+		{input: "678,Ходьба,1h30m,pool=25", wantErr: input.ErrInvalidParam},
+		{input: "5km,Ходьба,1h30m", wantErr: input.ErrInvalidDistance},
+		{input: "6000,Теннис,1h00m", wantErr: input.ErrUnknownActivity},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.input, func() {
+			_, err := ComputeTraining(tt.input, profile.Profile{Weight: 75.0, Height: 1.75})
+			assert.ErrorIs(suite.T(), err, tt.wantErr)
+		})
+	}
+
+	_, err := ComputeTraining("6000,Теннис,1h00m", profile.Profile{Weight: 75.0, Height: 1.75})
+	var parseErr *input.ParseError
+	if assert.ErrorAs(suite.T(), err, &parseErr) {
+		assert.Equal(suite.T(), input.FieldActivity, parseErr.Field)
+		assert.Equal(suite.T(), "Теннис", parseErr.Value)
+		assert.Equal(suite.T(), 2, parseErr.Pos)
+	}
+
+	_, err = ComputeTraining("5km,Ходьба,1h30m", profile.Profile{Weight: 75.0, Height: 1.75})
+	if assert.ErrorAs(suite.T(), err, &parseErr) {
+		assert.Equal(suite.T(), input.FieldDistance, parseErr.Field)
+		assert.Equal(suite.T(), "5", parseErr.Value)
+	}
+
+	for range 10 {
+		_, err = ComputeTraining("6000,Ходьба,1h00m,pool=25,length=50,stroke=crawl", profile.Profile{Weight: 75.0, Height: 1.75})
+		if assert.ErrorAs(suite.T(), err, &parseErr) {
+			assert.Equal(suite.T(), "length", parseErr.Field, "о неверных параметрах сообщается в порядке сортировки")
+		}
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestTrainingJSON() {
+	training := Training{Type: "Бег", Duration: time.Hour, Distance: 10, Speed: 10, Calories: 750}
+
+	data, err := json.Marshal(training)
+	assert.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"type":"Бег","duration_s":3600,"distance_km":10,"speed_kmh":10,"calories_kcal":750}`, string(data))
+
+	var got Training
+	assert.NoError(suite.T(), json.Unmarshal(data, &got))
+	assert.Equal(suite.T(), training, got)
+
+	swim, err := ComputeTraining("40,Плавание,30m", profile.Profile{Weight: 75.0, Height: 1.75})
+	assert.NoError(suite.T(), err)
+
+	data, err = json.Marshal(swim)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(data), `"pace_s":180,"pace_distance_km":0.1`)
+}
+
+func (suite *SpentCaloriesTestSuite) TestSplits() {
+	assert.Nil(suite.T(), EstimateSplits(0, time.Hour))
+
+	splits := EstimateSplits(12, time.Hour)
+	assert.Equal(suite.T(), []Split{
+		{Distance: 1, Time: 5 * time.Minute},
+		{Distance: 5, Time: 25 * time.Minute},
+		{Distance: 10, Time: 50 * time.Minute},
+		{Distance: HalfMarathon, Time: time.Hour + 45*time.Minute + 29*time.Second},
+	}, splits)
+
+	assert.Equal(suite.T(), "5 км", splits[1].Label(i18n.Russian))
+	assert.Equal(suite.T(), "half marathon", splits[3].Label(i18n.English))
+	assert.Equal(suite.T(), "5:32", FormatTime(5*time.Minute+32*time.Second))
+	assert.Equal(suite.T(), "1:45:29", FormatTime(splits[3].Time))
+
+	data, err := json.Marshal(splits[1])
+	assert.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"distance_km":5,"time_s":1500}`, string(data))
+}
+
+func (suite *SpentCaloriesTestSuite) TestTrainingSplits() {
+	p := profile.Profile{Weight: 75.0, Height: 1.75}
+
+	run, err := ComputeWorkout(Running, Workout{Distance: 10, Duration: 50 * time.Minute}, p)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5*time.Minute, run.Pace)
+	assert.Equal(suite.T(), 1.0, run.PaceDistance)
+	if assert.Len(suite.T(), run.Splits, len(SplitDistances)) {
+		assert.Equal(suite.T(), 5*time.Minute, run.Splits[0].Time)
+	}
+	assert.NotContains(suite.T(), run.String(), "Темп", "вывод String для бега не меняется")
+
+	data, err := json.Marshal(run)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(data), `"pace_s":300,"pace_distance_km":1`)
+	var got Training
+	assert.NoError(suite.T(), json.Unmarshal(data, &got))
+	assert.Equal(suite.T(), run.Pace, got.Pace)
+	assert.Equal(suite.T(), run.Splits, got.Splits)
+
+	walk, err := ComputeTraining("6000,Ходьба,1h00m", p)
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), walk.Splits, "прогноз рассчитывается только для бега")
+	assert.Zero(suite.T(), walk.PaceDistance, "темп рассчитывается только для бега и плавания")
+}
+
+func (suite *SpentCaloriesTestSuite) TestComputeWorkout() {
+	p := profile.Profile{Weight: 75.0, Height: 1.75}
+
+	run, err := ComputeWorkout("Бег", Workout{Distance: 10, Duration: time.Hour}, p)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 10.0, run.Distance, 1e-9)
+	assert.InDelta(suite.T(), 10.0, run.Speed, 1e-9)
+	assert.InDelta(suite.T(), 750.0, run.Calories, 1e-9, "калории по формуле бега от измеренной скорости")
+
+	walk, err := ComputeWorkout("Ходьба", Workout{Distance: 5, Duration: time.Hour}, p)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 187.5, walk.Calories, 1e-9)
+
+	_, err = ComputeWorkout("Бег", Workout{Duration: time.Hour}, p)
+	assert.Error(suite.T(), err, "нет ни шагов, ни дистанции")
+
+	_, err = ComputeWorkout("Теннис", Workout{Distance: 5, Duration: time.Hour}, p)
+	assert.ErrorIs(suite.T(), err, input.ErrUnknownActivity)
+}
+
+func (suite *SpentCaloriesTestSuite) TestHeartRateCalories() {
+	male := profile.Profile{Weight: 80.0, Height: 1.8, Age: 30, Sex: profile.Male}
+	female := profile.Profile{Weight: 60.0, Height: 1.65, Age: 30, Sex: profile.Female}
+
+	wantMale := (-55.0969 + 0.6309*150 + 0.1988*80 + 0.2017*30) / 4.184 * 60
+	wantFemale := (-20.4022 + 0.4472*150 - 0.1263*60 + 0.074*30) / 4.184 * 60
+
+	calories, err := HeartRateCalories(150, time.Hour, male)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), wantMale, calories, 1e-9)
+
+	calories, err = HeartRateCalories(150, time.Hour, female)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), wantFemale, calories, 1e-9)
+
+	training, err := ComputeTraining("10000,Бег,1h00m,hr=150", male)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), wantMale, training.Calories, 1e-9, "пульс заменяет формулу бега")
+	assert.InDelta(suite.T(), 8.1, training.Distance, 1e-9, "дистанция считается как обычно")
+
+	training, err = ComputeTraining("40,Плавание,1h00m,hr=150", female)
+	assert.NoError(suite.T(), err, "параметр hr допустим для любого вида тренировки")
+	assert.InDelta(suite.T(), wantFemale, training.Calories, 1e-9)
+
+	calories, err = SpentCalories(Walking, Workout{Steps: 6000, Duration: time.Hour, Params: map[string]string{"hr": "150"}}, male)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), wantMale, calories, 1e-9)
+
+	tests := []struct {
+		name string
+		data string
+		p    profile.Profile
+		is   error
+	}{
+		{name: "не указан возраст", data: "10000,Бег,1h00m,hr=150", p: profile.Profile{Weight: 80, Height: 1.8, Sex: profile.Male}},
+		{name: "не указан пол", data: "10000,Бег,1h00m,hr=150", p: profile.Profile{Weight: 80, Height: 1.8, Age: 30}},
+		{name: "пульс не число", data: "10000,Бег,1h00m,hr=много", p: male, is: input.ErrInvalidParam},
+		{name: "слишком низкий пульс", data: "10000,Бег,1h00m,hr=20", p: male, is: input.ErrInvalidParam},
+		{name: "слишком высокий пульс", data: "10000,Бег,1h00m,hr=260", p: male, is: input.ErrInvalidParam},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			_, err := ComputeTraining(tt.data, tt.p)
+			assert.Error(suite.T(), err)
+			if tt.is != nil {
+				assert.ErrorIs(suite.T(), err, tt.is)
+			}
+		})
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestCalorieModels() {
+	p := profile.Profile{Weight: 75.0, Height: 1.75}
+
+	formula, err := ComputeTraining("6000,Ходьба,1h00m", p)
+	assert.NoError(suite.T(), err)
+
+	p.CalorieModel = Formula{}
+	explicit, err := ComputeTraining("6000,Ходьба,1h00m", p)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), formula, explicit, "исходная формула используется по умолчанию")
+
+	p.CalorieModel = METTable{}
+	tests := []struct {
+		name    string
+		input   string
+		wantMET float64
+	}{
+		{name: "медленная ходьба", input: "3000,Ходьба,1h00m", wantMET: 2.0}, // 2.36 км/ч
+		{name: "ходьба", input: "6000,Ходьба,1h00m", wantMET: 3.0},           // 4.725 км/ч
+		{name: "быстрая ходьба", input: "10000,Ходьба,1h00m", wantMET: 7.0},  // 7.875 км/ч
+		{name: "медленный бег", input: "7000,Бег,1h00m", wantMET: 6.0},       // 5.51 км/ч
+		{name: "бег", input: "13000,Бег,1h00m", wantMET: 9.8},                // 10.24 км/ч
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := ComputeTraining(tt.input, p)
+			assert.NoError(suite.T(), err)
+			assert.InDelta(suite.T(), tt.wantMET*75.0*got.Duration.Hours(), got.Calories, 1e-9)
+		})
+	}
+
+	run, err := ComputeWorkout(Running, Workout{Distance: 10, Duration: 30 * time.Minute}, p)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 19.0*75.0*0.5, run.Calories, 1e-9, "измеренная дистанция из трека")
+
+	cycling, err := ComputeTraining("10000,Велосипед,1h00m", p)
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 8.0*75.0, cycling.Calories, 1e-9, "модель не влияет на виды тренировок со своими формулами")
+
+	_, err = METTable{}.Calories(p, "Гребля", 5, time.Hour)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestParseCalorieModel() {
+	m, err := ParseCalorieModel("MET")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), METTable{}, m)
+
+	m, err = ParseCalorieModel("formula")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Formula{}, m)
+
+	_, err = ParseCalorieModel("keytel")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestLocalization() {
+	p := profile.Profile{Weight: 84.6, Height: 1.87}
+
+	for _, name := range []string{"Run", "run", "Бег"} {
+		training, err := ComputeTraining("6000,"+name+",1h00m", p)
+		assert.NoError(suite.T(), err, name)
+		assert.Equal(suite.T(), Running, training.Type, "название на любом языке приводится к основному")
+	}
+
+	training, err := ComputeTraining("3456,Walk,3h00m", p)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), training.String(), training.Text(i18n.Russian, units.Metric))
+	assert.Equal(suite.T(),
+		"Training type: Walk\nDuration: 3.00 h.\nDistance: 2.91 km.\nSpeed: 0.97 km/h\nCalories burned: 123.02\n",
+		training.Text(i18n.English, units.Metric))
+
+	swim, err := ComputeTraining("40,Swim,30m", p)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), swim.Text(i18n.English, units.Metric), "Pace: 3:00 /100 m\n")
+	assert.Contains(suite.T(), swim.Text(i18n.Russian, units.Metric), "Темп: 3:00 /100 м\n")
+}
+
+func (suite *SpentCaloriesTestSuite) TestImperialUnits() {
+	training := Training{Type: Running, Duration: time.Hour, Distance: 10, Speed: 10, Calories: 750}
+
+	assert.Equal(suite.T(),
+		"Training type: Run\nDuration: 1.00 h.\nDistance: 6.21 mi.\nSpeed: 6.21 mph\nCalories burned: 750.00\n",
+		training.Text(i18n.English, units.Imperial))
+	assert.Contains(suite.T(), training.Text(i18n.Russian, units.Imperial), "Дистанция: 6.21 ми.\nСкорость: 6.21 ми/ч\n")
+}
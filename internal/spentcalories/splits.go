@@ -0,0 +1,75 @@
+package spentcalories
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+)
+
+// HalfMarathon — дистанция полумарафона в км.
+const HalfMarathon = 21.0975
+
+// SplitDistances — стандартные дистанции в км, для которых оценивается время.
+var SplitDistances = []float64{1, 5, 10, HalfMarathon}
+
+// Split — оценка времени прохождения дистанции при темпе тренировки.
+type Split struct {
+	Distance float64       // дистанция в км
+	Time     time.Duration // время прохождения
+}
+
+// Label возвращает название дистанции на языке lang, например "5 км" или "полумарафон".
+func (s Split) Label(lang i18n.Lang) string {
+	if s.Distance == HalfMarathon {
+		return lang.Sprintf(i18n.SplitHalfMarathon)
+	}
+	return fmt.Sprintf("%g %s", s.Distance, lang.Sprintf(i18n.PaceKm))
+}
+
+// splitJSON — представление оценки в JSON. Единицы измерения указаны в названиях полей.
+type splitJSON struct {
+	DistanceKm float64 `json:"distance_km"`
+	TimeS      float64 `json:"time_s"`
+}
+
+// MarshalJSON кодирует оценку в JSON со временем в секундах.
+func (s Split) MarshalJSON() ([]byte, error) {
+	return json.Marshal(splitJSON{DistanceKm: s.Distance, TimeS: s.Time.Seconds()})
+}
+
+// UnmarshalJSON декодирует оценку, закодированную MarshalJSON.
+func (s *Split) UnmarshalJSON(data []byte) error {
+	var v splitJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*s = Split{Distance: v.DistanceKm, Time: time.Duration(v.TimeS * float64(time.Second))}
+	return nil
+}
+
+// EstimateSplits оценивает время прохождения стандартных дистанций SplitDistances
+// при равномерном движении, если за duration пройдено distance км.
+// Для нулевой дистанции возвращается nil.
+func EstimateSplits(distance float64, duration time.Duration) []Split {
+	if distance <= 0 {
+		return nil
+	}
+
+	splits := make([]Split, 0, len(SplitDistances))
+	for _, d := range SplitDistances {
+		splits = append(splits, Split{Distance: d, Time: pace(distance, d, duration)})
+	}
+	return splits
+}
+
+// FormatTime форматирует время в виде минуты:секунды, а начиная с часа — часы:минуты:секунды.
+func FormatTime(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	if seconds < 3600 {
+		return FormatPace(d)
+	}
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
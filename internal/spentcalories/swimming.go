@@ -0,0 +1,96 @@
+package spentcalories
+
+import (
+	"errors"
+
+	"github.com/Yandex-Practicum/tracker/internal/input"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Swimming — название плавания.
+const Swimming = "Плавание"
+
+// Константы для расчёта плавания.
+const (
+	poolParam         = "pool"   // параметр с длиной бассейна в метрах.
+	strokeParam       = "stroke" // параметр со стилем плавания.
+	defaultPoolLength = 25.0     // длина бассейна по умолчанию в метрах.
+	defaultStroke     = "вольный"
+	swimmingPaceKm    = 0.1 // дистанция в км, на которую рассчитывается темп плавания.
+)
+
+// strokeMETs — значения MET для стилей плавания (Compendium of Physical Activities).
+var strokeMETs = map[string]float64{
+	"вольный":      5.8,
+	"freestyle":    5.8,
+	"брасс":        5.3,
+	"breaststroke": 5.3,
+	"спина":        4.8,
+	"backstroke":   4.8,
+	"баттерфляй":   13.8,
+	"butterfly":    13.8,
+}
+
+func init() {
+	mustRegister(Activity{
+		Name:         Swimming,
+		Aliases:      []string{"Swimming"},
+		Distance:     swimmingDistance,
+		Calories:     swimmingSpentCalories,
+		Params:       []string{poolParam, strokeParam},
+		Check:        checkStroke,
+		PaceDistance: swimmingPaceKm,
+	})
+}
+
+// swimmingDistance рассчитывает дистанцию по количеству бассейнов и длине бассейна.
+// Измеренная дистанция, если она есть, используется без изменений.
+func swimmingDistance(w Workout, p profile.Profile) (float64, error) {
+	if w.Distance > 0 {
+		return w.Distance, nil
+	}
+	pool, err := w.Float(poolParam, defaultPoolLength)
+	if err != nil {
+		return 0, err
+	}
+	return units.KmFromMeters(float64(w.Steps) * pool), nil
+}
+
+// swimmingSpentCalories рассчитывает калории по MET для выбранного стиля плавания.
+func swimmingSpentCalories(w Workout, p profile.Profile) (float64, error) {
+	if p.Weight <= 0 {
+		return 0, errors.New("вес должен быть больше нуля")
+	}
+	if w.Duration <= 0 {
+		return 0, errors.New("продолжительность должна быть больше нуля")
+	}
+
+	met, err := strokeMET(w)
+	if err != nil {
+		return 0, err
+	}
+
+	return met * p.Weight * w.Duration.Hours(), nil
+}
+
+// checkStroke проверяет стиль плавания до расчёта, в том числе когда калории считаются по пульсу.
+func checkStroke(w Workout) error {
+	_, err := strokeMET(w)
+	return err
+}
+
+// strokeMET возвращает MET для стиля плавания из параметров тренировки.
+func strokeMET(w Workout) (float64, error) {
+	stroke := defaultStroke
+	value, ok := w.Params[strokeParam]
+	if ok {
+		stroke = normalize(value)
+	}
+
+	met, known := strokeMETs[stroke]
+	if !known {
+		return 0, &input.ParseError{Field: strokeParam, Value: value, Err: input.ErrInvalidParam, Cause: errors.New("неизвестный стиль плавания")}
+	}
+	return met, nil
+}
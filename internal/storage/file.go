@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/Yandex-Practicum/tracker/internal/report"
+)
+
+// File — хранилище в файле формата JSON Lines: по одной записи в строке.
+// Новые записи дописываются в конец файла.
+type File struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFile возвращает хранилище в файле path. Файл создаётся при первом сохранении.
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Save дописывает в конец файла записи, которых в нём ещё нет.
+func (f *File) Save(entries ...report.Entry) error {
+	if err := check(entries); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	added := unsaved(stored, entries)
+	if len(added) == 0 {
+		return nil
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := write(file, added); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// write записывает записи в формате JSON Lines.
+func write(w io.Writer, entries []report.Entry) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// List возвращает все записи в порядке возрастания дат.
+func (f *File) List() ([]report.Entry, error) {
+	return f.Query(Query{})
+}
+
+// Query возвращает записи, подходящие под условия, в порядке возрастания дат.
+// Если файл ещё не создан, история считается пустой.
+func (f *File) Query(q Query) ([]report.Entry, error) {
+	f.mu.Lock()
+	entries, err := f.read()
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return filter(entries, q), nil
+}
+
+// read читает все записи из файла. Вызывается под f.mu.
+func (f *File) read() ([]report.Entry, error) {
+	file, err := os.Open(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []report.Entry
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e report.Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", f.path, line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
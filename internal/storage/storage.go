@@ -0,0 +1,144 @@
+// Package storage хранит историю активности между запусками трекера.
+package storage
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/report"
+)
+
+// ErrNoDate — запись без даты нельзя сохранить в историю.
+var ErrNoDate = errors.New("у записи не указана дата")
+
+// Query — условия выборки записей. Нулевые поля не ограничивают выборку.
+type Query struct {
+	From     time.Time // начало интервала включительно
+	To       time.Time // конец интервала, не включается
+	Activity string    // вид активности без учёта регистра
+}
+
+// Match сообщает, подходит ли запись под условия выборки.
+func (q Query) Match(e report.Entry) bool {
+	switch {
+	case !q.From.IsZero() && e.Date.Before(q.From):
+		return false
+	case !q.To.IsZero() && !e.Date.Before(q.To):
+		return false
+	case q.Activity != "" && !strings.EqualFold(strings.TrimSpace(q.Activity), e.Activity):
+		return false
+	}
+	return true
+}
+
+// Repository — хранилище истории активности.
+type Repository interface {
+	// Save добавляет записи в историю. Запись, которая полностью совпадает с уже сохранённой,
+	// пропускается, поэтому повторное сохранение не создаёт дубликатов.
+	// Записи без даты не сохраняются.
+	Save(entries ...report.Entry) error
+	// List возвращает все записи в порядке возрастания дат.
+	List() ([]report.Entry, error)
+	// Query возвращает записи, подходящие под условия, в порядке возрастания дат.
+	Query(q Query) ([]report.Entry, error)
+}
+
+// Memory — хранилище в памяти. Нулевое значение готово к использованию.
+type Memory struct {
+	mu      sync.RWMutex
+	entries []report.Entry
+}
+
+// Save добавляет в историю записи, которых в ней ещё нет.
+func (m *Memory) Save(entries ...report.Entry) error {
+	if err := check(entries); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = append(m.entries, unsaved(m.entries, entries)...)
+	return nil
+}
+
+// List возвращает все записи в порядке возрастания дат.
+func (m *Memory) List() ([]report.Entry, error) {
+	return m.Query(Query{})
+}
+
+// Query возвращает записи, подходящие под условия, в порядке возрастания дат.
+func (m *Memory) Query(q Query) ([]report.Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return filter(m.entries, q), nil
+}
+
+// check проверяет, что записи можно сохранить.
+func check(entries []report.Entry) error {
+	for _, e := range entries {
+		if e.Date.IsZero() {
+			return ErrNoDate
+		}
+	}
+	return nil
+}
+
+// key — ключ записи в истории: все сохраняемые поля записи. Одна и та же запись, сохранённая
+// повторно, получает тот же ключ, а разные тренировки с одинаковыми датой и продолжительностью — разные.
+type key struct {
+	date     int64 // время начала в наносекундах Unix, не зависит от часового пояса
+	activity string
+	steps    int
+	duration time.Duration // с точностью до миллисекунды, которая сохраняется в JSON
+	distance float64
+	calories float64
+}
+
+func keyOf(e report.Entry) key {
+	return key{
+		date:     e.Date.UnixNano(),
+		activity: e.Activity,
+		steps:    e.Steps,
+		duration: e.Duration.Round(time.Millisecond),
+		distance: e.Distance,
+		calories: e.Calories,
+	}
+}
+
+// unsaved возвращает записи entries, которых ещё нет среди сохранённых записей stored.
+// Записи внутри entries между собой не сравниваются: одинаковые записи одного сохранения
+// считаются разными активностями.
+func unsaved(stored, entries []report.Entry) []report.Entry {
+	saved := make(map[key]bool, len(stored))
+	for _, e := range stored {
+		saved[keyOf(e)] = true
+	}
+
+	var added []report.Entry
+	for _, e := range entries {
+		if !saved[keyOf(e)] {
+			added = append(added, e)
+		}
+	}
+	return added
+}
+
+// filter отбирает записи по условиям и сортирует их по дате.
+// Записи с одинаковой датой остаются в порядке добавления.
+func filter(entries []report.Entry, q Query) []report.Entry {
+	var result []report.Entry
+	for _, e := range entries {
+		if q.Match(e) {
+			result = append(result, e)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b report.Entry) int {
+		return a.Date.Compare(b.Date)
+	})
+	return result
+}
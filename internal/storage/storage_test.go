@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type StorageTestSuite struct {
+	suite.Suite
+}
+
+func TestStorageSuite(t *testing.T) {
+	suite.Run(t, new(StorageTestSuite))
+}
+
+func date(day, hour int) time.Time {
+	return time.Date(2024, 5, day, hour, 0, 0, 0, time.UTC)
+}
+
+// testEntries — записи в порядке, отличном от хронологического.
+func testEntries() []report.Entry {
+	return []report.Entry{
+		{Date: date(3, 7), Activity: "Бег", Duration: time.Hour, Distance: 10, Calories: 700},
+		{Date: date(1, 8), Activity: report.DayActivity, Steps: 6000, Duration: time.Hour, Distance: 3.9, Calories: 150},
+		{Date: date(2, 18), Activity: "Ходьба", Duration: 30 * time.Minute, Distance: 3, Calories: 120},
+	}
+}
+
+// repositories возвращает проверяемые реализации хранилища.
+func (suite *StorageTestSuite) repositories() map[string]Repository {
+	return map[string]Repository{
+		"в памяти": &Memory{},
+		"в файле":  NewFile(filepath.Join(suite.T().TempDir(), "history.jsonl")),
+	}
+}
+
+func (suite *StorageTestSuite) TestSaveAndList() {
+	for name, repo := range suite.repositories() {
+		suite.Run(name, func() {
+			entries, err := repo.List()
+			require.NoError(suite.T(), err)
+			assert.Empty(suite.T(), entries, "новое хранилище пустое")
+
+			input := testEntries()
+			require.NoError(suite.T(), repo.Save(input[:2]...))
+			require.NoError(suite.T(), repo.Save(input[2]))
+
+			entries, err = repo.List()
+			require.NoError(suite.T(), err)
+			require.Len(suite.T(), entries, 3)
+			for i, want := range []report.Entry{input[1], input[2], input[0]} {
+				assert.True(suite.T(), want.Date.Equal(entries[i].Date))
+				assert.Equal(suite.T(), want.Activity, entries[i].Activity)
+				assert.Equal(suite.T(), want.Steps, entries[i].Steps)
+				assert.Equal(suite.T(), want.Duration, entries[i].Duration)
+				assert.InDelta(suite.T(), want.Calories, entries[i].Calories, 1e-9)
+			}
+		})
+	}
+}
+
+func (suite *StorageTestSuite) TestSaveSkipsDuplicates() {
+	for name, repo := range suite.repositories() {
+		suite.Run(name, func() {
+			require.NoError(suite.T(), repo.Save(testEntries()...))
+			require.NoError(suite.T(), repo.Save(testEntries()...))
+
+			entries, err := repo.List()
+			require.NoError(suite.T(), err)
+			assert.Len(suite.T(), entries, 3, "повторное сохранение не создаёт дубликатов")
+
+			same := testEntries()[0]
+			same.Date = same.Date.In(time.FixedZone("MSK", 3*60*60))
+			require.NoError(suite.T(), repo.Save(same))
+			entries, err = repo.List()
+			require.NoError(suite.T(), err)
+			assert.Len(suite.T(), entries, 3, "совпадение не зависит от часового пояса")
+
+			updated := testEntries()[0]
+			updated.Calories = 650
+			added := report.Entry{Date: date(4, 9), Activity: "Бег", Duration: time.Hour, Distance: 9, Calories: 600}
+			require.NoError(suite.T(), repo.Save(updated, added, added))
+
+			entries, err = repo.List()
+			require.NoError(suite.T(), err)
+			require.Len(suite.T(), entries, 6, "записи одного сохранения не объединяются")
+			assert.InDelta(suite.T(), 700.0, entries[2].Calories, 1e-9, "сохранённая запись не заменяется")
+			assert.InDelta(suite.T(), 650.0, entries[3].Calories, 1e-9)
+		})
+	}
+}
+
+func (suite *StorageTestSuite) TestSaveSameDayTrainings() {
+	for name, repo := range suite.repositories() {
+		suite.Run(name, func() {
+			morning := report.Entry{Date: date(1, 0), Activity: "Бег", Steps: 6000, Duration: time.Hour, Distance: 4.7, Calories: 400}
+			evening := report.Entry{Date: date(1, 0), Activity: "Бег", Steps: 3000, Duration: time.Hour, Distance: 2.4, Calories: 200}
+			require.NoError(suite.T(), repo.Save(morning))
+			require.NoError(suite.T(), repo.Save(evening))
+
+			entries, err := repo.List()
+			require.NoError(suite.T(), err)
+			require.Len(suite.T(), entries, 2, "разные тренировки за один день с одинаковой продолжительностью сохраняются обе")
+			assert.Equal(suite.T(), 6000, entries[0].Steps)
+			assert.Equal(suite.T(), 3000, entries[1].Steps)
+		})
+	}
+}
+
+func (suite *StorageTestSuite) TestQuery() {
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{name: "без условий", query: Query{}, want: []string{report.DayActivity, "Ходьба", "Бег"}},
+		{name: "с начала интервала", query: Query{From: date(2, 0)}, want: []string{"Ходьба", "Бег"}},
+		{name: "конец интервала не включается", query: Query{To: date(3, 7)}, want: []string{report.DayActivity, "Ходьба"}},
+		{name: "интервал в один день", query: Query{From: date(2, 0), To: date(3, 0)}, want: []string{"Ходьба"}},
+		{name: "по виду активности", query: Query{Activity: "бег"}, want: []string{"Бег"}},
+		{name: "ничего не найдено", query: Query{From: date(2, 0), Activity: report.DayActivity}, want: nil},
+	}
+
+	for name, repo := range suite.repositories() {
+		require.NoError(suite.T(), repo.Save(testEntries()...))
+
+		for _, tt := range tests {
+			suite.Run(name+"/"+tt.name, func() {
+				entries, err := repo.Query(tt.query)
+				require.NoError(suite.T(), err)
+
+				var got []string
+				for _, e := range entries {
+					got = append(got, e.Activity)
+				}
+				assert.Equal(suite.T(), tt.want, got)
+			})
+		}
+	}
+}
+
+func (suite *StorageTestSuite) TestSaveWithoutDate() {
+	for name, repo := range suite.repositories() {
+		suite.Run(name, func() {
+			err := repo.Save(testEntries()[0], report.Entry{Activity: "Бег", Duration: time.Hour})
+			assert.ErrorIs(suite.T(), err, ErrNoDate)
+
+			entries, err := repo.List()
+			require.NoError(suite.T(), err)
+			assert.Empty(suite.T(), entries, "при ошибке записи не сохраняются")
+		})
+	}
+}
+
+func (suite *StorageTestSuite) TestFilePersists() {
+	path := filepath.Join(suite.T().TempDir(), "history.jsonl")
+	require.NoError(suite.T(), NewFile(path).Save(testEntries()...))
+
+	entries, err := NewFile(path).List()
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), entries, 3, "история доступна из другого экземпляра хранилища")
+}
+
+func (suite *StorageTestSuite) TestFileInvalidLine() {
+	path := filepath.Join(suite.T().TempDir(), "history.jsonl")
+	require.NoError(suite.T(), os.WriteFile(path, []byte("{\"activity\":\"Бег\"}\n\nне json\n"), 0o644))
+
+	_, err := NewFile(path).List()
+	assert.ErrorContains(suite.T(), err, "history.jsonl:3")
+}
@@ -0,0 +1,86 @@
+package stride
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Model — модель длины шага. Выбранная модель хранится в профиле пользователя.
+type Model = profile.StrideModel
+
+// Fixed — постоянная длина шага в м.
+type Fixed float64
+
+// StrideLength возвращает постоянную длину шага.
+func (f Fixed) StrideLength(profile.Profile, string) float64 {
+	return float64(f)
+}
+
+// HeightBased — длина шага как доля роста пользователя.
+type HeightBased float64
+
+// StrideLength возвращает длину шага, рассчитанную по росту.
+func (h HeightBased) StrideLength(p profile.Profile, _ string) float64 {
+	return p.Height * float64(h)
+}
+
+// Calibrated использует откалиброванную длину шага для вида активности,
+// затем измеренную длину шага из профиля, а если нет ни того ни другого — модель Fallback.
+type Calibrated struct {
+	Fallback Model
+}
+
+// StrideLength возвращает длину шага с учётом калибровки.
+func (c Calibrated) StrideLength(p profile.Profile, activity string) float64 {
+	if stride, ok := p.CalibratedStride(activity); ok {
+		return stride
+	}
+	if p.StrideLength > 0 {
+		return p.StrideLength
+	}
+	if c.Fallback == nil {
+		return 0
+	}
+	return c.Fallback.StrideLength(p, activity)
+}
+
+// Модели по умолчанию.
+var (
+	// DefaultFixed — средняя длина шага, с которой DayActionInfo исторически выводит дистанцию.
+	DefaultFixed = Fixed(0.65)
+	// DefaultHeightBased — коэффициент роста, которым исторически пользуется пакет spentcalories.
+	DefaultHeightBased = HeightBased(0.45)
+	// Default — модель, которой daysteps и spentcalories пользуются, если в профиле модель не выбрана,
+	// поэтому одинаковое количество шагов в обоих пакетах даёт одинаковую дистанцию.
+	Default Model = DefaultHeightBased
+)
+
+// Resolve возвращает модель из профиля, а если она не выбрана — калиброванную модель поверх def.
+func Resolve(p profile.Profile, def Model) Model {
+	if p.StrideModel != nil {
+		return p.StrideModel
+	}
+	return Calibrated{Fallback: def}
+}
+
+// Distance возвращает дистанцию в км, пройденную за steps шагов.
+func Distance(m Model, p profile.Profile, activity string, steps int) float64 {
+	return units.KmFromMeters(float64(steps) * m.StrideLength(p, activity))
+}
+
+// Parse возвращает модель по названию: fixed, height или calibrated.
+// Калиброванная модель использует модель по росту, если калибровки нет.
+func Parse(name string) (Model, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "fixed":
+		return DefaultFixed, nil
+	case "height":
+		return DefaultHeightBased, nil
+	case "calibrated":
+		return Calibrated{Fallback: Default}, nil
+	}
+	return nil, fmt.Errorf("неизвестная модель длины шага: %q", name)
+}
@@ -0,0 +1,112 @@
+package stride
+
+import (
+	"testing"
+
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type StrideTestSuite struct {
+	suite.Suite
+}
+
+func TestStrideSuite(t *testing.T) {
+	suite.Run(t, new(StrideTestSuite))
+}
+
+func (suite *StrideTestSuite) TestStrideLength() {
+	p := profile.Profile{Weight: 75, Height: 1.75}
+	assert.NoError(suite.T(), p.Calibrate("Бег", 900, 1000))
+
+	measured := p
+	measured.StrideLength = 0.7
+
+	tests := []struct {
+		name     string
+		model    Model
+		profile  profile.Profile
+		activity string
+		want     float64
+	}{
+		{
+			name:     "постоянная длина шага",
+			model:    Fixed(0.65),
+			profile:  p,
+			activity: "Бег",
+			want:     0.65,
+		},
+		{
+			name:     "длина шага по росту",
+			model:    HeightBased(0.45),
+			profile:  p,
+			activity: "Бег",
+			want:     0.7875,
+		},
+		{
+			name:     "калибровка для вида активности",
+			model:    Calibrated{Fallback: Fixed(0.65)},
+			profile:  measured,
+			activity: "Бег",
+			want:     0.9,
+		},
+		{
+			name:     "измеренная длина шага без калибровки",
+			model:    Calibrated{Fallback: Fixed(0.65)},
+			profile:  measured,
+			activity: "Ходьба",
+			want:     0.7,
+		},
+		{
+			name:     "запасная модель",
+			model:    Calibrated{Fallback: HeightBased(0.45)},
+			profile:  p,
+			activity: "Ходьба",
+			want:     0.7875,
+		},
+		{
+			name:     "калибровка без запасной модели",
+			model:    Calibrated{},
+			profile:  p,
+			activity: "Ходьба",
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got := tt.model.StrideLength(tt.profile, tt.activity)
+			assert.InDelta(suite.T(), tt.want, got, 1e-9)
+		})
+	}
+}
+
+func (suite *StrideTestSuite) TestResolve() {
+	p := profile.Profile{Weight: 75, Height: 1.75}
+	assert.Equal(suite.T(), Calibrated{Fallback: Fixed(0.65)}, Resolve(p, Fixed(0.65)))
+
+	p.StrideModel = HeightBased(0.4)
+	assert.Equal(suite.T(), HeightBased(0.4), Resolve(p, Fixed(0.65)))
+}
+
+func (suite *StrideTestSuite) TestDistance() {
+	p := profile.Profile{Weight: 75, Height: 1.75}
+	assert.InDelta(suite.T(), 3.9, Distance(Fixed(0.65), p, "Ходьба", 6000), 1e-9)
+	assert.InDelta(suite.T(), 4.725, Distance(HeightBased(0.45), p, "Ходьба", 6000), 1e-9)
+}
+
+func (suite *StrideTestSuite) TestParse() {
+	for name, want := range map[string]Model{
+		"fixed":      DefaultFixed,
+		"Height":     DefaultHeightBased,
+		"calibrated": Calibrated{Fallback: DefaultHeightBased},
+	} {
+		got, err := Parse(name)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), want, got, "Parse(%q)", name)
+	}
+
+	_, err := Parse("random")
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,51 @@
+package trackfile
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+type gpxFile struct {
+	Tracks []struct {
+		Type     string `xml:"type"`
+		Segments []struct {
+			Points []struct {
+				Lat  float64   `xml:"lat,attr"`
+				Lon  float64   `xml:"lon,attr"`
+				Time time.Time `xml:"time"`
+				// HeartRate — пульс из расширения Garmin TrackPointExtension.
+				HeartRate int `xml:"extensions>TrackPointExtension>hr"`
+			} `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// readGPX читает трек из файла GPX 1.1. Точки без времени пропускаются.
+// Пульс читается из расширения Garmin TrackPointExtension, если оно есть.
+func readGPX(r io.Reader) (Track, error) {
+	var f gpxFile
+	if err := xml.NewDecoder(r).Decode(&f); err != nil {
+		return Track{}, err
+	}
+
+	var t Track
+	for _, trk := range f.Tracks {
+		if t.Sport == "" {
+			t.Sport = trk.Type
+		}
+		for _, seg := range trk.Segments {
+			var segment []Point
+			for _, pt := range seg.Points {
+				if pt.Time.IsZero() {
+					continue
+				}
+				segment = append(segment, Point{Lat: pt.Lat, Lon: pt.Lon, Time: pt.Time, HeartRate: pt.HeartRate})
+			}
+			if len(segment) > 0 {
+				t.Segments = append(t.Segments, segment)
+			}
+		}
+	}
+	return t, nil
+}
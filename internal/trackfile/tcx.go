@@ -0,0 +1,55 @@
+package trackfile
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+type tcxFile struct {
+	Activities []struct {
+		Sport string `xml:"Sport,attr"`
+		Laps  []struct {
+			Tracks []struct {
+				Points []struct {
+					Time     time.Time `xml:"Time"`
+					Position *struct {
+						Lat float64 `xml:"LatitudeDegrees"`
+						Lon float64 `xml:"LongitudeDegrees"`
+					} `xml:"Position"`
+					HeartRate int `xml:"HeartRateBpm>Value"`
+				} `xml:"Trackpoint"`
+			} `xml:"Track"`
+		} `xml:"Lap"`
+	} `xml:"Activities>Activity"`
+}
+
+// readTCX читает трек из файла Garmin TCX. Точки без координат или времени пропускаются.
+func readTCX(r io.Reader) (Track, error) {
+	var f tcxFile
+	if err := xml.NewDecoder(r).Decode(&f); err != nil {
+		return Track{}, err
+	}
+
+	var t Track
+	for _, activity := range f.Activities {
+		if t.Sport == "" {
+			t.Sport = activity.Sport
+		}
+		for _, lap := range activity.Laps {
+			for _, trk := range lap.Tracks {
+				var segment []Point
+				for _, pt := range trk.Points {
+					if pt.Position == nil || pt.Time.IsZero() {
+						continue
+					}
+					segment = append(segment, Point{Lat: pt.Position.Lat, Lon: pt.Position.Lon, Time: pt.Time, HeartRate: pt.HeartRate})
+				}
+				if len(segment) > 0 {
+					t.Segments = append(t.Segments, segment)
+				}
+			}
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,169 @@
+package trackfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+// Форматы файлов треков.
+const (
+	FormatGPX = "gpx"
+	FormatTCX = "tcx"
+)
+
+const earthRadius = 6371.0088 // средний радиус Земли в км.
+
+// sports сопоставляет виды спорта из файлов треков с видами тренировок.
+var sports = map[string]string{
+	"running":  spentcalories.Running,
+	"run":      spentcalories.Running,
+	"walking":  spentcalories.Walking,
+	"walk":     spentcalories.Walking,
+	"hiking":   spentcalories.Walking,
+	"biking":   spentcalories.Cycling,
+	"cycling":  spentcalories.Cycling,
+	"swimming": spentcalories.Swimming,
+}
+
+// Point — точка трека.
+type Point struct {
+	Lat  float64   // широта в градусах
+	Lon  float64   // долгота в градусах
+	Time time.Time // время прохождения точки
+
+	HeartRate int // пульс в уд/мин, 0 — не записан
+}
+
+// Track — трек тренировки, прочитанный из файла.
+type Track struct {
+	Sport    string    // вид спорта, указанный в файле
+	Segments [][]Point // непрерывные участки трека
+}
+
+// Read читает трек в формате format из r.
+func Read(r io.Reader, format string) (Track, error) {
+	var (
+		t   Track
+		err error
+	)
+
+	switch format {
+	case FormatGPX:
+		t, err = readGPX(r)
+	case FormatTCX:
+		t, err = readTCX(r)
+	default:
+		return Track{}, fmt.Errorf("неизвестный формат трека: %q", format)
+	}
+	if err != nil {
+		return Track{}, fmt.Errorf("не удалось прочитать %s: %w", format, err)
+	}
+
+	if len(t.points()) < 2 {
+		return Track{}, errors.New("в треке должно быть хотя бы две точки со временем")
+	}
+	return t, nil
+}
+
+// ReadFile читает трек из файла. Формат определяется по расширению .gpx или .tcx.
+func ReadFile(path string) (Track, error) {
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+
+	f, err := os.Open(path)
+	if err != nil {
+		return Track{}, err
+	}
+	defer f.Close()
+
+	return Read(f, format)
+}
+
+// Activity возвращает вид тренировки, соответствующий виду спорта из файла,
+// или пустую строку, если соответствие неизвестно.
+func (t Track) Activity() string {
+	return sports[strings.ToLower(strings.TrimSpace(t.Sport))]
+}
+
+// Start возвращает время первой точки трека.
+func (t Track) Start() time.Time {
+	points := t.points()
+	if len(points) == 0 {
+		return time.Time{}
+	}
+	return points[0].Time
+}
+
+// Duration возвращает время между первой и последней точками трека.
+func (t Track) Duration() time.Duration {
+	points := t.points()
+	if len(points) == 0 {
+		return 0
+	}
+	return points[len(points)-1].Time.Sub(points[0].Time)
+}
+
+// Distance возвращает длину трека в км. Расстояние между участками не учитывается.
+func (t Track) Distance() float64 {
+	var total float64
+	for _, segment := range t.Segments {
+		for i := 1; i < len(segment); i++ {
+			total += haversine(segment[i-1], segment[i])
+		}
+	}
+	return total
+}
+
+// HeartRate возвращает средний пульс в уд/мин по точкам, в которых он записан,
+// или 0, если пульс в треке не записан.
+func (t Track) HeartRate() float64 {
+	var sum, n int
+	for _, pt := range t.points() {
+		if pt.HeartRate > 0 {
+			sum += pt.HeartRate
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return float64(sum) / float64(n)
+}
+
+// Workout возвращает данные трека для расчёта тренировки в пакете spentcalories.
+func (t Track) Workout() spentcalories.Workout {
+	return spentcalories.Workout{
+		Distance: t.Distance(),
+		Duration: t.Duration(),
+	}
+}
+
+// points возвращает все точки трека по порядку.
+func (t Track) points() []Point {
+	var points []Point
+	for _, segment := range t.Segments {
+		points = append(points, segment...)
+	}
+	return points
+}
+
+// haversine возвращает расстояние между точками в км по формуле гаверсинусов.
+func haversine(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(b.Lon - a.Lon)
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
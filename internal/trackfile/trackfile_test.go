@@ -0,0 +1,120 @@
+package trackfile
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const testGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk>
+    <type>running</type>
+    <trkseg>
+      <trkpt lat="55.000" lon="37.0"><time>2024-05-01T07:00:00Z</time></trkpt>
+      <trkpt lat="55.009" lon="37.0"><time>2024-05-01T07:05:00Z</time></trkpt>
+      <trkpt lat="55.018" lon="37.0"><time>2024-05-01T07:10:00Z</time></trkpt>
+      <trkpt lat="55.500" lon="37.0"></trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+const testTCX = `<?xml version="1.0" encoding="UTF-8"?>
+<TrainingCenterDatabase xmlns="http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2">
+  <Activities>
+    <Activity Sport="Biking">
+      <Lap StartTime="2024-05-02T18:00:00Z">
+        <Track>
+          <Trackpoint>
+            <Time>2024-05-02T18:00:00Z</Time>
+            <Position><LatitudeDegrees>55.000</LatitudeDegrees><LongitudeDegrees>37.0</LongitudeDegrees></Position>
+          </Trackpoint>
+          <Trackpoint>
+            <Time>2024-05-02T18:01:00Z</Time>
+          </Trackpoint>
+          <Trackpoint>
+            <Time>2024-05-02T18:30:00Z</Time>
+            <Position><LatitudeDegrees>55.090</LatitudeDegrees><LongitudeDegrees>37.0</LongitudeDegrees></Position>
+          </Trackpoint>
+        </Track>
+      </Lap>
+    </Activity>
+  </Activities>
+</TrainingCenterDatabase>`
+
+type TrackFileTestSuite struct {
+	suite.Suite
+}
+
+func TestTrackFileSuite(t *testing.T) {
+	suite.Run(t, new(TrackFileTestSuite))
+}
+
+func (suite *TrackFileTestSuite) TestReadGPX() {
+	track, err := Read(strings.NewReader(testGPX), FormatGPX)
+	require.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), spentcalories.Running, track.Activity())
+	assert.Equal(suite.T(), time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC), track.Start())
+	assert.Equal(suite.T(), 10*time.Minute, track.Duration())
+	assert.InDelta(suite.T(), 2.0015, track.Distance(), 0.001)
+}
+
+func (suite *TrackFileTestSuite) TestReadTCX() {
+	track, err := Read(strings.NewReader(testTCX), FormatTCX)
+	require.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), spentcalories.Cycling, track.Activity())
+	assert.Equal(suite.T(), 30*time.Minute, track.Duration())
+	assert.InDelta(suite.T(), 10.0075, track.Distance(), 0.001)
+}
+
+func (suite *TrackFileTestSuite) TestReadErrors() {
+	_, err := Read(strings.NewReader(testGPX), "fit")
+	assert.Error(suite.T(), err)
+
+	_, err = Read(strings.NewReader("not xml"), FormatGPX)
+	assert.Error(suite.T(), err)
+
+	_, err = Read(strings.NewReader(`<gpx><trk><trkseg><trkpt lat="1" lon="1"><time>2024-05-01T07:00:00Z</time></trkpt></trkseg></trk></gpx>`), FormatGPX)
+	assert.Error(suite.T(), err, "одной точки недостаточно")
+}
+
+func (suite *TrackFileTestSuite) TestComputeWorkout() {
+	track, err := Read(strings.NewReader(testGPX), FormatGPX)
+	require.NoError(suite.T(), err)
+
+	p := profile.Profile{Weight: 75.0, Height: 1.75}
+	training, err := spentcalories.ComputeWorkout(track.Activity(), track.Workout(), p)
+	require.NoError(suite.T(), err)
+
+	assert.InDelta(suite.T(), track.Distance(), training.Distance, 1e-9)
+	assert.InDelta(suite.T(), 12.009, training.Speed, 0.01)
+	assert.InDelta(suite.T(), 75.0*training.Speed*10/60, training.Calories, 1e-9)
+}
+
+func (suite *TrackFileTestSuite) TestHeartRate() {
+	track, err := Read(strings.NewReader(testGPX), FormatGPX)
+	require.NoError(suite.T(), err)
+	assert.Zero(suite.T(), track.HeartRate(), "пульс не записан")
+
+	gpx := `<gpx xmlns="http://www.topografix.com/GPX/1/1" xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v1"><trk><trkseg>
+  <trkpt lat="55.000" lon="37.0"><time>2024-05-01T07:00:00Z</time><extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>140</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions></trkpt>
+  <trkpt lat="55.009" lon="37.0"><time>2024-05-01T07:05:00Z</time></trkpt>
+  <trkpt lat="55.018" lon="37.0"><time>2024-05-01T07:10:00Z</time><extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>160</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions></trkpt>
+</trkseg></trk></gpx>`
+	track, err = Read(strings.NewReader(gpx), FormatGPX)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 150.0, track.HeartRate(), 1e-9, "точки без пульса не учитываются")
+
+	tcx := strings.ReplaceAll(testTCX, "<Time>2024-05-02T18:30:00Z</Time>", "<Time>2024-05-02T18:30:00Z</Time><HeartRateBpm><Value>130</Value></HeartRateBpm>")
+	track, err = Read(strings.NewReader(tcx), FormatTCX)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 130.0, track.HeartRate(), 1e-9)
+}
@@ -0,0 +1,139 @@
+// Package units содержит системы единиц измерения и преобразования между ними.
+// Расчёты в остальных пакетах ведутся в метрических единицах: км, км/ч, кг и м,
+// а пересчёт для ввода и вывода выполняется здесь.
+package units
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+)
+
+// Коэффициенты пересчёта.
+const (
+	MInKm        = 1000       // количество метров в километре.
+	KmInMile     = 1.609344   // количество километров в миле.
+	KgInPound    = 0.45359237 // количество килограммов в фунте.
+	MInFoot      = 0.3048     // количество метров в футе.
+	InchesInFoot = 12         // количество дюймов в футе.
+)
+
+// KmFromMeters переводит метры в километры.
+func KmFromMeters(m float64) float64 {
+	return m / MInKm
+}
+
+// MetersFromKm переводит километры в метры.
+func MetersFromKm(km float64) float64 {
+	return km * MInKm
+}
+
+// System — система единиц измерения.
+type System string
+
+// Поддерживаемые системы единиц.
+const (
+	Metric   System = "metric"   // км, км/ч, кг, м
+	Imperial System = "imperial" // мили, мили/ч, фунты, футы и дюймы
+)
+
+// Parse разбирает название системы единиц: metric или imperial.
+func Parse(s string) (System, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "metric", "si":
+		return Metric, nil
+	case "imperial", "us":
+		return Imperial, nil
+	}
+	return "", fmt.Errorf("неизвестная система единиц: %q", s)
+}
+
+// Distance переводит дистанцию в км в единицы системы s.
+func (s System) Distance(km float64) float64 {
+	if s == Imperial {
+		return km / KmInMile
+	}
+	return km
+}
+
+// Speed переводит скорость в км/ч в единицы системы s.
+func (s System) Speed(kmh float64) float64 {
+	return s.Distance(kmh)
+}
+
+// Pace переводит время на километр во время на единицу дистанции системы s: километр или милю.
+func (s System) Pace(perKm time.Duration) time.Duration {
+	if s == Imperial {
+		return time.Duration(float64(perKm) * KmInMile).Round(time.Second)
+	}
+	return perKm
+}
+
+// Kilograms переводит вес в единицах системы s в килограммы.
+func (s System) Kilograms(weight float64) float64 {
+	if s == Imperial {
+		return weight * KgInPound
+	}
+	return weight
+}
+
+// DistanceUnit возвращает обозначение единицы дистанции на языке lang.
+func (s System) DistanceUnit(lang i18n.Lang) string {
+	if s == Imperial {
+		return lang.Sprintf(i18n.UnitMile)
+	}
+	return lang.Sprintf(i18n.UnitKm)
+}
+
+// SpeedUnit возвращает обозначение единицы скорости на языке lang.
+func (s System) SpeedUnit(lang i18n.Lang) string {
+	if s == Imperial {
+		return lang.Sprintf(i18n.UnitMph)
+	}
+	return lang.Sprintf(i18n.UnitKmh)
+}
+
+// feetAndInches — рост в футах и дюймах: 5'11", 5' 11, 5ft11in, 5ft или 71in.
+var feetAndInches = regexp.MustCompile(`^(?:(\d+(?:\.\d+)?)\s*(?:'|ft))?\s*(?:(\d+(?:\.\d+)?)\s*(?:"|''|in)?)?$`)
+
+// ParseHeight разбирает рост в единицах системы s и возвращает его в метрах.
+// В метрической системе рост указывается в метрах, например 1.75.
+// В имперской — в футах и дюймах, например 5'11", 5ft11in или 71in;
+// число без обозначения единиц отклоняется, потому что неясно, футы это или дюймы.
+func (s System) ParseHeight(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	if s != Imperial {
+		height, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("неверный рост %q: %w", value, err)
+		}
+		return height, nil
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return 0, fmt.Errorf("неверный рост %q: %w", value, errors.New(`не указаны единицы, ожидается, например, 5'11" или 71in`))
+	}
+
+	parts := feetAndInches.FindStringSubmatch(strings.ToLower(value))
+	if parts == nil || (parts[1] == "" && parts[2] == "") {
+		return 0, fmt.Errorf("неверный рост %q: %w", value, errors.New(`ожидается, например, 5'11" или 71in`))
+	}
+
+	var feet, inches float64
+	if parts[1] != "" {
+		feet, _ = strconv.ParseFloat(parts[1], 64)
+	}
+	if parts[2] != "" {
+		inches, _ = strconv.ParseFloat(parts[2], 64)
+	}
+	return (feet + inches/InchesInFoot) * MInFoot, nil
+}
@@ -0,0 +1,89 @@
+package units
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type UnitsTestSuite struct {
+	suite.Suite
+}
+
+func TestUnitsSuite(t *testing.T) {
+	suite.Run(t, new(UnitsTestSuite))
+}
+
+func (suite *UnitsTestSuite) TestParse() {
+	s, err := Parse("Imperial")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Imperial, s)
+
+	s, err = Parse("metric")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Metric, s)
+
+	_, err = Parse("nautical")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *UnitsTestSuite) TestConversions() {
+	assert.InDelta(suite.T(), 1.5, KmFromMeters(1500), 1e-9)
+	assert.InDelta(suite.T(), 100.0, MetersFromKm(0.1), 1e-9)
+
+	assert.InDelta(suite.T(), 10.0, Metric.Distance(10), 1e-9)
+	assert.InDelta(suite.T(), 6.2137, Imperial.Distance(10), 1e-4)
+	assert.InDelta(suite.T(), 26.2188, Imperial.Distance(42.195), 1e-4, "марафон в милях")
+	assert.InDelta(suite.T(), 6.2137, Imperial.Speed(10), 1e-4)
+
+	assert.InDelta(suite.T(), 75.0, Metric.Kilograms(75), 1e-9)
+	assert.InDelta(suite.T(), 79.3786, Imperial.Kilograms(175), 1e-4)
+
+	assert.Equal(suite.T(), 5*time.Minute, Metric.Pace(5*time.Minute))
+	assert.Equal(suite.T(), 8*time.Minute+3*time.Second, Imperial.Pace(5*time.Minute), "темп на милю")
+}
+
+func (suite *UnitsTestSuite) TestUnitNames() {
+	assert.Equal(suite.T(), "км", Metric.DistanceUnit(i18n.Russian))
+	assert.Equal(suite.T(), "mi", Imperial.DistanceUnit(i18n.English))
+	assert.Equal(suite.T(), "км/ч", Metric.SpeedUnit(i18n.Russian))
+	assert.Equal(suite.T(), "mph", Imperial.SpeedUnit(i18n.English))
+}
+
+func (suite *UnitsTestSuite) TestParseHeight() {
+	tests := []struct {
+		system  System
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{system: Metric, input: "1.75", want: 1.75},
+		{system: Metric, input: "", want: 0},
+		{system: Metric, input: "5'11\"", wantErr: true},
+		{system: Imperial, input: "5'11\"", want: 1.8034},
+		{system: Imperial, input: "5' 11", want: 1.8034},
+		{system: Imperial, input: "5ft11in", want: 1.8034},
+		{system: Imperial, input: "5ft", want: 1.524},
+		{system: Imperial, input: "71in", want: 1.8034},
+		{system: Imperial, input: "5.5ft", want: 1.6764},
+		{system: Imperial, input: "5.5", wantErr: true},
+		{system: Imperial, input: "71", wantErr: true},
+		{system: Imperial, input: "пять футов", wantErr: true},
+		{system: Imperial, input: "'", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		suite.Run(string(tt.system)+" "+tt.input, func() {
+			got, err := tt.system.ParseHeight(tt.input)
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+				return
+			}
+			assert.NoError(suite.T(), err)
+			assert.InDelta(suite.T(), tt.want, got, 1e-4)
+		})
+	}
+}